@@ -0,0 +1,57 @@
+// Command synth-fixture turns a user-provided GitHub Actions workflow
+// (e.g. from a bug report) into an anonymized fixture under
+// evals/testdata/fixtures/gha plus a scaffolded eval to paste into
+// evals/migrate_gha_test.go.
+//
+// Usage:
+//
+//	go run ./cmd/synth-fixture -name flaky-deploy -keep build,deploy path/to/workflow.yml
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rwx-cloud/skills/evals"
+)
+
+func main() {
+	name := flag.String("name", "", "fixture name (directory under testdata/fixtures/gha)")
+	keep := flag.String("keep", "", "comma-separated jobs to keep (default: all)")
+	flag.Parse()
+
+	if *name == "" || flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: synth-fixture -name <fixture> [-keep job,job] <workflow.yml>")
+		os.Exit(2)
+	}
+	workflow, err := os.ReadFile(flag.Arg(0))
+	if err != nil {
+		fatal(err)
+	}
+
+	opts := evals.FixtureSynthOptions{Name: *name}
+	if *keep != "" {
+		opts.KeepJobs = strings.Split(*keep, ",")
+	}
+	fixture, err := evals.SynthesizeFixture(workflow, opts)
+	if err != nil {
+		fatal(err)
+	}
+
+	dir, err := fixture.WriteFixture(filepath.Join("evals", "testdata", "fixtures", "gha"))
+	if err != nil {
+		fatal(err)
+	}
+	fmt.Printf("wrote fixture to %s\n\n", dir)
+	fmt.Println("add this eval to evals/migrate_gha_test.go:")
+	fmt.Println()
+	fmt.Println(fixture.EvalStub)
+}
+
+func fatal(err error) {
+	fmt.Fprintln(os.Stderr, "synth-fixture:", err)
+	os.Exit(1)
+}
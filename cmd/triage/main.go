@@ -0,0 +1,153 @@
+// Command triage is a small REPL for poking at saved eval bundles —
+// the transcript JSON plus the workdir snapshot written by the harness —
+// so failure analysis doesn't require re-running expensive evals or
+// hand-parsing JSON.
+//
+// Usage:
+//
+//	go run ./cmd/triage evals/artifacts/TestMigrateFromGHA_GoMatrix.json
+//
+// The workdir snapshot is found next to the transcript
+// (<name>_workdir/); override with -workdir.
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/rwx-cloud/skills/evals"
+)
+
+func main() {
+	workDir := flag.String("workdir", "", "workdir snapshot containing the generated .rwx configs")
+	flag.Parse()
+	if flag.NArg() != 1 {
+		fmt.Fprintln(os.Stderr, "usage: triage [-workdir dir] <transcript.json>")
+		os.Exit(2)
+	}
+	transcript := flag.Arg(0)
+	result, err := evals.LoadTranscript(transcript)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "triage:", err)
+		os.Exit(1)
+	}
+	if *workDir == "" {
+		*workDir = strings.TrimSuffix(transcript, ".json") + "_workdir"
+	}
+
+	fmt.Printf("loaded %d events from %s\n", len(result.Events), transcript)
+	fmt.Println(`commands: summary | turn N | config | assert | baseline <eval> | quit`)
+
+	scanner := bufio.NewScanner(os.Stdin)
+	for {
+		fmt.Print("triage> ")
+		if !scanner.Scan() {
+			return
+		}
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "quit", "exit", "q":
+			return
+		case "summary":
+			showSummary(result)
+		case "turn":
+			if len(fields) != 2 {
+				fmt.Println("usage: turn N")
+				continue
+			}
+			n, err := strconv.Atoi(fields[1])
+			if err != nil || n < 0 || n >= len(result.Events) {
+				fmt.Printf("turn must be 0..%d\n", len(result.Events)-1)
+				continue
+			}
+			showEvent(result.Events[n])
+		case "config":
+			showConfig(*workDir)
+		case "assert":
+			reassert(*workDir)
+		case "baseline":
+			if len(fields) != 2 {
+				fmt.Println("usage: baseline <eval>")
+				continue
+			}
+			showBaselineDiff(fields[1], result)
+		default:
+			fmt.Printf("unknown command %q\n", fields[0])
+		}
+	}
+}
+
+func showSummary(result *evals.ExecutionResult) {
+	fmt.Printf("events:      %d\n", len(result.Events))
+	fmt.Printf("tools used:  %v\n", result.ToolsUsed())
+	fmt.Printf("skills used: %v\n", result.SkillsUsed())
+	fmt.Printf("tokens:      %d\n", result.TotalTokens())
+	fmt.Printf("cost:        $%.4f\n", result.CostUSD())
+	if res := result.ResultEvent(); res != nil {
+		fmt.Printf("result:      %s/%s (error=%v)\n", res.Type, res.Subtype, res.IsError)
+	}
+}
+
+func showEvent(ev evals.ClaudeEvent) {
+	data, err := json.MarshalIndent(ev, "", "  ")
+	if err != nil {
+		fmt.Println("marshal:", err)
+		return
+	}
+	fmt.Println(string(data))
+}
+
+func showConfig(workDir string) {
+	cfg, err := evals.LoadRWXConfigs(workDir)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	rendered, err := cfg.Render()
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Print(rendered)
+}
+
+// reassert runs a basic sanity suite against the saved configs. Eval-
+// specific invariants live in Go tests; this answers "is the saved
+// config structurally sane" without an agent run.
+func reassert(workDir string) {
+	cfg, err := evals.LoadRWXConfigs(workDir)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	for _, a := range []evals.ConfigAssertion{
+		evals.MinTaskCount(1),
+		evals.HasProblemMatcherFreeOutput(),
+		evals.AllTasksHaveFilters(),
+	} {
+		if msgs, failed := evals.Probe(a, cfg); failed {
+			fmt.Printf("FAIL %s: %s\n", a.Name, strings.Join(msgs, "; "))
+		} else {
+			fmt.Printf("ok   %s\n", a.Name)
+		}
+	}
+}
+
+func showBaselineDiff(eval string, result *evals.ExecutionResult) {
+	baseline, err := evals.LoadBaseline(eval)
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Printf("tokens:   %d (baseline %d)\n", result.TotalTokens(), baseline.TotalTokens)
+	fmt.Printf("duration: %dms (baseline %dms)\n", result.Duration.Milliseconds(), baseline.DurationMS)
+	fmt.Printf("recorded: %s\n", baseline.RecordedAt)
+}
@@ -0,0 +1,75 @@
+package evals
+
+import "context"
+
+// PluginDirRun is the outcome of one plugin dir in an A/B run.
+type PluginDirRun struct {
+	PluginDir string
+	Result    *ExecutionResult
+	Err       error
+}
+
+// RunAcrossPluginDirs invokes run once per plugin dir and pairs each
+// outcome with its dir. Pointing the dirs at git worktrees of the skills
+// repo at two commits and comparing the runs is the core workflow for
+// validating a skill change. run typically wraps RunClaudeWith with
+// RunOptions.PluginDir set and a fresh workdir per side.
+func RunAcrossPluginDirs(ctx context.Context, dirs []string, run func(ctx context.Context, pluginDir string) (*ExecutionResult, error)) []PluginDirRun {
+	runs := make([]PluginDirRun, 0, len(dirs))
+	for _, dir := range dirs {
+		result, err := run(ctx, dir)
+		runs = append(runs, PluginDirRun{PluginDir: dir, Result: result, Err: err})
+	}
+	return runs
+}
+
+// MetricDelta is one run metric compared across an A/B pair.
+type MetricDelta struct {
+	Metric string
+	A, B   float64
+}
+
+// CompareRuns returns the headline metric deltas between two runs of the
+// same eval, in a fixed order.
+func CompareRuns(a, b *ExecutionResult) []MetricDelta {
+	return []MetricDelta{
+		{Metric: "total_tokens", A: float64(a.TotalTokens()), B: float64(b.TotalTokens())},
+		{Metric: "num_turns", A: float64(a.NumTurns()), B: float64(b.NumTurns())},
+		{Metric: "duration_ms", A: float64(a.Duration.Milliseconds()), B: float64(b.Duration.Milliseconds())},
+		{Metric: "cost_usd", A: a.CostUSD(), B: b.CostUSD()},
+		{Metric: "thinking_tokens", A: float64(a.ThinkingTokens()), B: float64(b.ThinkingTokens())},
+	}
+}
+
+// AssertionDelta is an assertion whose outcome differs between the two
+// sides of an A/B run.
+type AssertionDelta struct {
+	Name string
+	// PassedA and PassedB are the outcomes on each side.
+	PassedA, PassedB bool
+	// Messages are the failing side's messages, for triage.
+	Messages []string
+}
+
+// CompareAssertions probes every assertion against both configs and
+// returns only the ones whose outcome changed — the regressions and
+// fixes a skill change caused, with the noise of unchanged assertions
+// stripped.
+func CompareAssertions(a, b *RWXConfig, assertions ...ConfigAssertion) []AssertionDelta {
+	var deltas []AssertionDelta
+	for _, assertion := range assertions {
+		messagesA, failedA := Probe(assertion, a)
+		messagesB, failedB := Probe(assertion, b)
+		if failedA == failedB {
+			continue
+		}
+		delta := AssertionDelta{Name: assertion.Name, PassedA: !failedA, PassedB: !failedB}
+		if failedA {
+			delta.Messages = messagesA
+		} else {
+			delta.Messages = messagesB
+		}
+		deltas = append(deltas, delta)
+	}
+	return deltas
+}
@@ -0,0 +1,85 @@
+package evals
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestRunAcrossPluginDirs(t *testing.T) {
+	runs := RunAcrossPluginDirs(context.Background(), []string{"/worktrees/main/plugins/rwx", "/worktrees/branch/plugins/rwx"},
+		func(ctx context.Context, pluginDir string) (*ExecutionResult, error) {
+			if pluginDir == "/worktrees/branch/plugins/rwx" {
+				return nil, fmt.Errorf("claude exited with 1")
+			}
+			return resultWith(ClaudeEvent{Type: "result", Subtype: "success"}), nil
+		})
+	if len(runs) != 2 {
+		t.Fatalf("got %d runs", len(runs))
+	}
+	if runs[0].PluginDir != "/worktrees/main/plugins/rwx" || runs[0].Err != nil || runs[0].Result == nil {
+		t.Errorf("first run = %+v", runs[0])
+	}
+	if runs[1].Err == nil {
+		t.Errorf("second run = %+v", runs[1])
+	}
+}
+
+func TestCompareRuns(t *testing.T) {
+	a := &ExecutionResult{
+		Duration: 2 * time.Second,
+		Events: []ClaudeEvent{
+			{Type: "result", Subtype: "success", NumTurns: 10, TotalCostUSD: 0.50,
+				Usage: &ClaudeUsage{InputTokens: 900, OutputTokens: 100}},
+		},
+	}
+	b := &ExecutionResult{
+		Duration: time.Second,
+		Events: []ClaudeEvent{
+			{Type: "result", Subtype: "success", NumTurns: 8, TotalCostUSD: 0.25,
+				Usage: &ClaudeUsage{InputTokens: 700, OutputTokens: 50}},
+		},
+	}
+
+	deltas := CompareRuns(a, b)
+	byMetric := map[string]MetricDelta{}
+	for _, d := range deltas {
+		byMetric[d.Metric] = d
+	}
+	if d := byMetric["total_tokens"]; d.A != 1000 || d.B != 750 {
+		t.Errorf("total_tokens = %+v", d)
+	}
+	if d := byMetric["num_turns"]; d.A != 10 || d.B != 8 {
+		t.Errorf("num_turns = %+v", d)
+	}
+	if d := byMetric["cost_usd"]; d.A != 0.50 || d.B != 0.25 {
+		t.Errorf("cost_usd = %+v", d)
+	}
+}
+
+func TestCompareAssertions(t *testing.T) {
+	before := mustParse(t, `
+tasks:
+  - key: test
+    run: go test ./...
+`)
+	after := mustParse(t, `
+tasks:
+  - key: code
+    call: git/clone 1.6.6
+  - key: test
+    run: go test ./...
+`)
+
+	deltas := CompareAssertions(before, after, ClonesRepo(), AnyTaskRunContains("go test"))
+	if len(deltas) != 1 {
+		t.Fatalf("deltas = %+v", deltas)
+	}
+	if deltas[0].Name != "uses_package_git_clone" || deltas[0].PassedA || !deltas[0].PassedB {
+		t.Errorf("delta = %+v", deltas[0])
+	}
+	if len(deltas[0].Messages) == 0 {
+		t.Error("failing side's messages should be carried")
+	}
+}
@@ -0,0 +1,99 @@
+package evals
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// evalDeps maps eval test names to the repo-relative paths whose changes
+// affect them: the skill under test, its fixtures, shared playbooks.
+var (
+	evalDepsMu sync.Mutex
+	evalDeps   = map[string][]string{}
+)
+
+// RegisterEvalDeps declares which paths an eval depends on. Paths are
+// repo-relative; a path names a file, a directory prefix, or a glob with
+// a trailing /** (see pathMatchesGlob). Evals with no registered deps
+// always run.
+func RegisterEvalDeps(test string, paths ...string) {
+	evalDepsMu.Lock()
+	defer evalDepsMu.Unlock()
+	evalDeps[test] = append(evalDeps[test], paths...)
+}
+
+// AffectedByChanges reports whether any changed file falls under the
+// eval's registered deps. Evals without registered deps are treated as
+// affected — running too much is safer than silently skipping.
+func AffectedByChanges(test string, changed []string) bool {
+	evalDepsMu.Lock()
+	deps := evalDeps[test]
+	evalDepsMu.Unlock()
+	if len(deps) == 0 {
+		return true
+	}
+	for _, file := range changed {
+		for _, dep := range deps {
+			if file == dep || strings.HasPrefix(file, dep+"/") || pathMatchesGlob(dep, file) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// changedFiles lists the repo-relative files changed since baseRef,
+// via git diff against the merge base.
+func changedFiles(baseRef string) ([]string, error) {
+	cmd := exec.Command("git", "diff", "--name-only", baseRef+"...HEAD")
+	cmd.Dir = filepath.Join(evalsPackageDir(), "..")
+	out, err := cmd.Output()
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("git diff %s: %s", baseRef, ee.Stderr)
+		}
+		return nil, err
+	}
+	var files []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if line != "" {
+			files = append(files, line)
+		}
+	}
+	return files, nil
+}
+
+var (
+	changedOnce  sync.Once
+	changedList  []string
+	changedError error
+)
+
+// SkipIfUnaffected skips the eval when EVALS_AFFECTED_ONLY=1 and none of
+// the files changed since EVALS_BASE_REF (default origin/main) fall
+// under the eval's registered deps, so a docs tweak in one skill doesn't
+// trigger the entire multi-dollar suite.
+func SkipIfUnaffected(t *testing.T) {
+	t.Helper()
+	if os.Getenv("EVALS_AFFECTED_ONLY") == "" {
+		return
+	}
+	baseRef := os.Getenv("EVALS_BASE_REF")
+	if baseRef == "" {
+		baseRef = "origin/main"
+	}
+	changedOnce.Do(func() {
+		changedList, changedError = changedFiles(baseRef)
+	})
+	if changedError != nil {
+		t.Fatalf("computing changed files against %s: %v", baseRef, changedError)
+	}
+	if !AffectedByChanges(t.Name(), changedList) {
+		t.Skipf("no files changed since %s affect this eval", baseRef)
+	}
+}
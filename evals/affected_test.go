@@ -0,0 +1,33 @@
+package evals
+
+import "testing"
+
+func TestAffectedByChanges(t *testing.T) {
+	RegisterEvalDeps("TestAffected_Fake",
+		"plugins/rwx/skills/migrate-from-gha",
+		"evals/testdata/fixtures/gha/**",
+	)
+
+	cases := []struct {
+		name    string
+		changed []string
+		want    bool
+	}{
+		{"skill file", []string{"plugins/rwx/skills/migrate-from-gha/SKILL.md"}, true},
+		{"fixture glob", []string{"evals/testdata/fixtures/gha/go-matrix/go.mod"}, true},
+		{"unrelated skill", []string{"plugins/rwx/skills/review-gha-migration/SKILL.md"}, false},
+		{"readme only", []string{"README.md"}, false},
+		{"nothing changed", nil, false},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := AffectedByChanges("TestAffected_Fake", tc.changed); got != tc.want {
+				t.Errorf("AffectedByChanges(%v) = %v, want %v", tc.changed, got, tc.want)
+			}
+		})
+	}
+
+	if !AffectedByChanges("TestWithNoDeps", []string{"README.md"}) {
+		t.Error("evals without registered deps should always run")
+	}
+}
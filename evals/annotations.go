@@ -0,0 +1,108 @@
+package evals
+
+import (
+	"strings"
+	"sync"
+)
+
+// Assertion statuses beyond plain pass/fail, recorded in the results
+// profile so known gaps stay visible without making CI red.
+const (
+	StatusPass       = "pass"
+	StatusFail       = "fail"
+	StatusSkip       = "skip"
+	StatusXFail      = "xfail"
+	StatusXPass      = "xpass"
+	StatusWarn       = "warn"
+	StatusQuarantine = "quarantine"
+)
+
+// statusOverrides lets annotation wrappers report a non-binary outcome
+// back to AssertConfig, keyed by assertion name.
+var statusOverrides sync.Map
+
+func overrideStatus(assertion, status string) {
+	statusOverrides.Store(assertion, status)
+}
+
+func takeStatusOverride(assertion string) (string, bool) {
+	v, ok := statusOverrides.LoadAndDelete(assertion)
+	if !ok {
+		return "", false
+	}
+	return v.(string), true
+}
+
+// ExpectedFail marks an assertion as a known gap. A failing child is
+// reported as XFAIL (logged with its tracking issue, not failing the
+// run); a passing child is reported as XPASS and fails so the annotation
+// gets removed once the gap is closed.
+func ExpectedFail(reason, trackingIssue string, a ConfigAssertion) ConfigAssertion {
+	name := "xfail_" + a.Name
+	return ConfigAssertion{
+		Name: name,
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			probe := &probeTB{}
+			a.Check(probe, cfg)
+			if probe.failed {
+				overrideStatus(name, StatusXFail)
+				t.Logf("XFAIL (%s, %s): %s", reason, trackingIssue, strings.Join(probe.messages, "; "))
+				return
+			}
+			overrideStatus(name, StatusXPass)
+			t.Errorf("XPASS: %s unexpectedly passed; remove the ExpectedFail annotation (%s, %s)",
+				a.Name, reason, trackingIssue)
+		},
+	}
+}
+
+// Warn downgrades an assertion to warn-only severity: failures are
+// logged and recorded as "warn" in the results profile, but never fail
+// the run. Useful for aspirational invariants we want to track before
+// enforcing them.
+func Warn(a ConfigAssertion) ConfigAssertion {
+	name := "warn_" + a.Name
+	return ConfigAssertion{
+		Name: name,
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			probe := &probeTB{}
+			a.Check(probe, cfg)
+			if probe.failed {
+				overrideStatus(name, StatusWarn)
+				t.Logf("WARN: %s: %s", a.Name, strings.Join(probe.messages, "; "))
+			}
+		},
+	}
+}
+
+// SkipWhen skips an assertion when cond returns true, e.g. checks that
+// need RWX credentials the environment doesn't have. The skip is recorded
+// distinctly rather than silently passing.
+func SkipWhen(cond func() bool, reason string, a ConfigAssertion) ConfigAssertion {
+	return ConfigAssertion{
+		Name: a.Name,
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			if cond() {
+				overrideStatus(a.Name, StatusSkip)
+				t.Logf("SKIP: %s", reason)
+				return
+			}
+			a.Check(t, cfg)
+		},
+	}
+}
+
+// statusFor derives the recorded status for an executed assertion,
+// preferring any override reported by an annotation wrapper.
+func statusFor(assertion string, failed bool) string {
+	if status, ok := takeStatusOverride(assertion); ok {
+		return status
+	}
+	if failed {
+		return StatusFail
+	}
+	return StatusPass
+}
@@ -0,0 +1,67 @@
+package evals
+
+import "testing"
+
+func TestExpectedFail(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+
+	failing := UsesPackage("ruby/install")
+	wrapped := ExpectedFail("ruby support not implemented", "rwx-cloud/skills#999", failing)
+	probe := runAssertion(wrapped, cfg)
+	if probe.failed {
+		t.Errorf("XFAIL should not fail the run: %v", probe.messages)
+	}
+	if status, _ := takeStatusOverride(wrapped.Name); status != StatusXFail {
+		t.Errorf("status = %q, want xfail", status)
+	}
+
+	passing := UsesPackage("git/clone")
+	wrapped = ExpectedFail("stale annotation", "rwx-cloud/skills#999", passing)
+	probe = runAssertion(wrapped, cfg)
+	if !probe.failed {
+		t.Error("XPASS should fail so the annotation gets removed")
+	}
+	if status, _ := takeStatusOverride(wrapped.Name); status != StatusXPass {
+		t.Errorf("status = %q, want xpass", status)
+	}
+}
+
+func TestWarn(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+
+	wrapped := Warn(UsesPackage("ruby/install"))
+	probe := runAssertion(wrapped, cfg)
+	if probe.failed {
+		t.Errorf("warn-only assertion should not fail the run: %v", probe.messages)
+	}
+	if status, _ := takeStatusOverride(wrapped.Name); status != StatusWarn {
+		t.Errorf("status = %q, want warn", status)
+	}
+
+	wrapped = Warn(UsesPackage("git/clone"))
+	probe = runAssertion(wrapped, cfg)
+	if probe.failed {
+		t.Errorf("passing child should pass: %v", probe.messages)
+	}
+	if status, ok := takeStatusOverride(wrapped.Name); ok {
+		t.Errorf("passing child should not override status, got %q", status)
+	}
+}
+
+func TestSkipWhen(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	a := UsesPackage("ruby/install")
+
+	probe := runAssertion(SkipWhen(func() bool { return true }, "no RWX credentials", a), cfg)
+	if probe.failed {
+		t.Errorf("skipped assertion should not fail: %v", probe.messages)
+	}
+	if status, _ := takeStatusOverride(a.Name); status != StatusSkip {
+		t.Errorf("status = %q, want skip", status)
+	}
+
+	probe = runAssertion(SkipWhen(func() bool { return false }, "no RWX credentials", a), cfg)
+	if !probe.failed {
+		t.Error("condition false should run the child assertion")
+	}
+}
@@ -0,0 +1,192 @@
+package evals
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Finding is one structured anti-pattern hit from AnalyzeConfig. Review
+// evals assert on findings directly; report-oriented evals render them
+// with FindingsReport.
+type Finding struct {
+	// Kind is one of the Finding* constants.
+	Kind string
+	// Task is the offending task key.
+	Task string
+	// Message explains the problem and the expected shape.
+	Message string
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: task %q: %s", f.Kind, f.Task, f.Message)
+}
+
+// Anti-pattern kinds produced by AnalyzeConfig.
+const (
+	// FindingMegaTask flags one task doing everything, which forfeits
+	// caching and parallelism between steps.
+	FindingMegaTask = "mega-task"
+	// FindingMissingDep flags test tasks that don't depend on any
+	// toolchain-install task.
+	FindingMissingDep = "missing-dep"
+	// FindingInlineInstall flags dependency installation inlined into a
+	// build/test task instead of its own cacheable task.
+	FindingInlineInstall = "inline-install"
+	// FindingParallelizable flags independent steps (lint, test, build)
+	// serialized inside one task.
+	FindingParallelizable = "parallelizable"
+)
+
+// megaTaskCommandLimit is the number of commands in one run block past
+// which a task is considered a mega-task.
+const megaTaskCommandLimit = 10
+
+var (
+	testCommandRE    = regexp.MustCompile(`\b(?:go test|npm (?:run )?test|yarn test|pytest|rspec|cargo test|mix test)\b`)
+	installCommandRE = regexp.MustCompile(`\b(?:npm (?:install|ci)|yarn install|pnpm install|pip install|bundle install|go mod download|cargo fetch)\b`)
+	stepGroupREs     = map[string]*regexp.Regexp{
+		"lint":  regexp.MustCompile(`\b(?:go vet|golangci-lint|eslint|rubocop|flake8|ruff)\b`),
+		"test":  testCommandRE,
+		"build": regexp.MustCompile(`\b(?:go build|npm run build|yarn build|cargo build|make build)\b`),
+	}
+)
+
+// AnalyzeConfig inspects a generated config for common bad shapes and
+// returns structured findings, in task order.
+func AnalyzeConfig(cfg *RWXConfig) []Finding {
+	var findings []Finding
+	installKeys := installTaskKeys(cfg)
+
+	for _, task := range cfg.Tasks {
+		if task.Run == "" {
+			continue
+		}
+		if n := len(commandLines(task.Run)); n > megaTaskCommandLimit {
+			findings = append(findings, Finding{
+				Kind:    FindingMegaTask,
+				Task:    task.Key,
+				Message: fmt.Sprintf("runs %d commands in one task; split into per-concern tasks to regain caching and parallelism", n),
+			})
+		}
+		if testCommandRE.MatchString(task.Run) && len(installKeys) > 0 && !usesAny(task, installKeys) {
+			findings = append(findings, Finding{
+				Kind:    FindingMissingDep,
+				Task:    task.Key,
+				Message: fmt.Sprintf("runs tests without depending on an install task (have: %s)", strings.Join(installKeys, ", ")),
+			})
+		}
+		if installCommandRE.MatchString(task.Run) && (testCommandRE.MatchString(task.Run) || stepGroupREs["build"].MatchString(task.Run)) {
+			findings = append(findings, Finding{
+				Kind:    FindingInlineInstall,
+				Task:    task.Key,
+				Message: "installs dependencies inline; move installation to its own task so it caches independently",
+			})
+		}
+		if groups := stepGroups(task.Run); len(groups) > 1 {
+			findings = append(findings, Finding{
+				Kind:    FindingParallelizable,
+				Task:    task.Key,
+				Message: fmt.Sprintf("serializes independent steps (%s); split them into sibling tasks", strings.Join(groups, ", ")),
+			})
+		}
+	}
+	return findings
+}
+
+// FindingsReport renders the findings for inclusion in a review-eval
+// transcript or prompt.
+func FindingsReport(findings []Finding) string {
+	if len(findings) == 0 {
+		return "no anti-patterns found"
+	}
+	var b strings.Builder
+	for _, f := range findings {
+		fmt.Fprintf(&b, "- %s\n", f)
+	}
+	return strings.TrimRight(b.String(), "\n")
+}
+
+// commandLines returns the non-empty, non-comment lines of a run block.
+func commandLines(run string) []string {
+	var out []string
+	for _, line := range strings.Split(run, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		out = append(out, line)
+	}
+	return out
+}
+
+// installTaskKeys returns the keys of tasks that install a toolchain or
+// dependencies via a package call.
+func installTaskKeys(cfg *RWXConfig) []string {
+	var keys []string
+	for _, task := range cfg.Tasks {
+		name, _ := splitCall(task.Call)
+		if strings.HasSuffix(name, "/install") || name == "rwx/tool-versions" {
+			keys = append(keys, task.Key)
+		}
+	}
+	return keys
+}
+
+func usesAny(task RWXTask, keys []string) bool {
+	for _, key := range keys {
+		if task.Use.Contains(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// stepGroups reports which independent step groups (lint, test, build)
+// a run block mixes, sorted for stable messages.
+func stepGroups(run string) []string {
+	var groups []string
+	for _, name := range []string{"build", "lint", "test"} {
+		if stepGroupREs[name].MatchString(run) {
+			groups = append(groups, name)
+		}
+	}
+	return groups
+}
+
+// NoMegaTasks asserts the config has no task flagged as a mega-task.
+func NoMegaTasks() ConfigAssertion {
+	return findingAssertion("no_mega_tasks", FindingMegaTask)
+}
+
+// ParallelizableTasksSplit asserts no task serializes independent steps
+// that should be sibling tasks.
+func ParallelizableTasksSplit() ConfigAssertion {
+	return findingAssertion("parallelizable_tasks_split", FindingParallelizable)
+}
+
+// TestsDependOnInstallTasks asserts test tasks depend on the config's
+// toolchain-install tasks.
+func TestsDependOnInstallTasks() ConfigAssertion {
+	return findingAssertion("tests_depend_on_install_tasks", FindingMissingDep)
+}
+
+// NoInlineDependencyInstalls asserts dependency installation lives in
+// its own task rather than inside build/test tasks.
+func NoInlineDependencyInstalls() ConfigAssertion {
+	return findingAssertion("no_inline_dependency_installs", FindingInlineInstall)
+}
+
+func findingAssertion(name, kind string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: name,
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, f := range AnalyzeConfig(cfg) {
+				if f.Kind == kind {
+					t.Errorf("%s", f)
+				}
+			}
+		},
+	}
+}
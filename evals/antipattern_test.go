@@ -0,0 +1,122 @@
+package evals
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAnalyzeConfigClean(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if findings := AnalyzeConfig(cfg); len(findings) != 0 {
+		t.Errorf("findings = %v", findings)
+	}
+	if got := FindingsReport(nil); got != "no anti-patterns found" {
+		t.Errorf("empty report = %q", got)
+	}
+}
+
+func TestAnalyzeConfigMegaTask(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: everything
+    run: |
+      # set up
+      apt-get update
+      apt-get install -y curl
+      curl -fsSL -o go.tar.gz https://example.com/go-1.22.tar.gz
+      tar -xzf go.tar.gz
+      export PATH=$PATH:/usr/local/go/bin
+      go mod download
+      go vet ./...
+      go build ./...
+      go test ./...
+      cd web && npm ci
+      npm run build
+      npm test
+`)
+	findings := AnalyzeConfig(cfg)
+	if !hasFinding(findings, FindingMegaTask, "everything") {
+		t.Errorf("no mega-task finding in %v", findings)
+	}
+	if probe := runAssertion(NoMegaTasks(), cfg); !probe.failed {
+		t.Error("NoMegaTasks should fail")
+	}
+	if !strings.Contains(FindingsReport(findings), "mega-task") {
+		t.Errorf("report = %q", FindingsReport(findings))
+	}
+}
+
+func TestAnalyzeConfigMissingDepAndInlineInstall(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: go
+    call: golang/install 1.2.0
+  - key: test
+    run: |
+      go mod download
+      go test ./...
+`)
+	findings := AnalyzeConfig(cfg)
+	if !hasFinding(findings, FindingMissingDep, "test") {
+		t.Errorf("no missing-dep finding in %v", findings)
+	}
+	if !hasFinding(findings, FindingInlineInstall, "test") {
+		t.Errorf("no inline-install finding in %v", findings)
+	}
+
+	if probe := runAssertion(TestsDependOnInstallTasks(), cfg); !probe.failed {
+		t.Error("TestsDependOnInstallTasks should fail")
+	}
+	if probe := runAssertion(NoInlineDependencyInstalls(), cfg); !probe.failed {
+		t.Error("NoInlineDependencyInstalls should fail")
+	}
+
+	cfg.Tasks[1].Use = FlexStrings{"go"}
+	if hasFinding(AnalyzeConfig(cfg), FindingMissingDep, "test") {
+		t.Error("missing-dep finding persists after adding the dependency")
+	}
+}
+
+func TestAnalyzeConfigParallelizable(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: go
+    call: golang/install 1.2.0
+  - key: check
+    use: [go]
+    run: |
+      go vet ./...
+      go test ./...
+`)
+	findings := AnalyzeConfig(cfg)
+	if !hasFinding(findings, FindingParallelizable, "check") {
+		t.Errorf("no parallelizable finding in %v", findings)
+	}
+	if probe := runAssertion(ParallelizableTasksSplit(), cfg); !probe.failed {
+		t.Error("ParallelizableTasksSplit should fail")
+	}
+
+	split := mustParse(t, `
+tasks:
+  - key: go
+    call: golang/install 1.2.0
+  - key: lint
+    use: [go]
+    run: go vet ./...
+  - key: test
+    use: [go]
+    run: go test ./...
+`)
+	if probe := runAssertion(ParallelizableTasksSplit(), split); probe.failed {
+		t.Errorf("split config flagged: %v", probe.messages)
+	}
+}
+
+func hasFinding(findings []Finding, kind, task string) bool {
+	for _, f := range findings {
+		if f.Kind == kind && f.Task == task {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,301 @@
+package evals
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+// APIRunner drives the Anthropic Messages API directly instead of the
+// claude CLI, emulating file tools inside the workdir. It trades the
+// CLI's full agent harness for deterministic temperature control, exact
+// token accounting, and the ability to run where the CLI cannot be
+// installed.
+type APIRunner struct {
+	// Model is the API model identifier. Required.
+	Model string
+	// Temperature is passed through verbatim; zero means deterministic
+	// sampling, which is usually what evals want.
+	Temperature float64
+	// MaxTurns bounds the tool-use loop. Defaults to 30.
+	MaxTurns int
+	// System is prepended as the system prompt. SkillSystemPrompt builds
+	// one from a skill in this repository.
+	System string
+	// APIKey defaults to ANTHROPIC_API_KEY.
+	APIKey string
+	// BaseURL defaults to the public API endpoint. Tests point it at a
+	// local server.
+	BaseURL string
+	// HTTPClient defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+const defaultAPIBaseURL = "https://api.anthropic.com"
+
+// apiTools is the file-operation toolset exposed to the model. It is
+// deliberately minimal: enough to read a fixture project and write RWX
+// configs, nothing that escapes the workdir.
+var apiTools = []map[string]any{
+	{
+		"name":        "read_file",
+		"description": "Read a file relative to the project root.",
+		"input_schema": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{"path": map[string]any{"type": "string"}},
+			"required":   []string{"path"},
+		},
+	},
+	{
+		"name":        "write_file",
+		"description": "Write a file relative to the project root, creating parent directories.",
+		"input_schema": map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"path":    map[string]any{"type": "string"},
+				"content": map[string]any{"type": "string"},
+			},
+			"required": []string{"path", "content"},
+		},
+	},
+	{
+		"name":        "list_files",
+		"description": "List all files under the project root.",
+		"input_schema": map[string]any{
+			"type":       "object",
+			"properties": map[string]any{},
+		},
+	},
+}
+
+// SkillSystemPrompt builds a system prompt from a skill's SKILL.md in
+// this repository, so API runs exercise the same instructions the CLI
+// loads via the plugin.
+func SkillSystemPrompt(skill string) (string, error) {
+	path := filepath.Join(PluginDir(), "skills", skill, "SKILL.md")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", fmt.Errorf("loading skill %s: %w", skill, err)
+	}
+	return string(data), nil
+}
+
+// Run executes the tool-use loop until the model stops or MaxTurns is
+// reached, and returns a transcript in the same shape RunClaude
+// produces so the existing assertions and baselines apply unchanged.
+func (r *APIRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	if r.Model == "" {
+		return nil, fmt.Errorf("APIRunner.Model is required")
+	}
+	apiKey := r.APIKey
+	if apiKey == "" {
+		apiKey = os.Getenv("ANTHROPIC_API_KEY")
+	}
+	if apiKey == "" {
+		return nil, fmt.Errorf("no API key: set APIRunner.APIKey or ANTHROPIC_API_KEY")
+	}
+	maxTurns := r.MaxTurns
+	if maxTurns == 0 {
+		maxTurns = 30
+	}
+
+	messages := []map[string]any{
+		{"role": "user", "content": prompt},
+	}
+	var events []ClaudeEvent
+	usage := ClaudeUsage{}
+	start := time.Now()
+
+	for turn := 0; turn < maxTurns; turn++ {
+		resp, err := r.createMessage(ctx, apiKey, messages)
+		if err != nil {
+			return nil, err
+		}
+		usage.InputTokens += resp.Usage.InputTokens
+		usage.OutputTokens += resp.Usage.OutputTokens
+		events = append(events, ClaudeEvent{
+			Type:    "assistant",
+			Message: &ClaudeMessage{Role: "assistant", Content: resp.Content, Usage: resp.Usage},
+		})
+
+		if resp.StopReason != "tool_use" {
+			break
+		}
+
+		messages = append(messages, map[string]any{"role": "assistant", "content": resp.Content})
+		var results []map[string]any
+		for _, block := range resp.Content {
+			if block.Type != "tool_use" {
+				continue
+			}
+			output, toolErr := applyFileTool(workDir, block.Name, block.Input)
+			result := map[string]any{
+				"type":        "tool_result",
+				"tool_use_id": block.ID,
+				"content":     output,
+			}
+			if toolErr != nil {
+				result["content"] = toolErr.Error()
+				result["is_error"] = true
+			}
+			results = append(results, result)
+		}
+		messages = append(messages, map[string]any{"role": "user", "content": results})
+	}
+
+	elapsed := time.Since(start)
+	events = append(events, ClaudeEvent{
+		Type:       "result",
+		DurationMS: elapsed.Milliseconds(),
+		Usage:      &usage,
+	})
+	raw, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+	return &ExecutionResult{Events: events, Raw: raw, Duration: elapsed}, nil
+}
+
+// apiResponse is the subset of the Messages API response the runner
+// consumes.
+type apiResponse struct {
+	Content    []ContentBlock `json:"content"`
+	StopReason string         `json:"stop_reason"`
+	Usage      *ClaudeUsage   `json:"usage"`
+}
+
+func (r *APIRunner) createMessage(ctx context.Context, apiKey string, messages []map[string]any) (*apiResponse, error) {
+	body := map[string]any{
+		"model":       r.Model,
+		"max_tokens":  8192,
+		"temperature": r.Temperature,
+		"tools":       apiTools,
+		"messages":    messages,
+	}
+	if r.System != "" {
+		body["system"] = r.System
+	}
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+
+	baseURL := r.BaseURL
+	if baseURL == "" {
+		baseURL = defaultAPIBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, baseURL+"/v1/messages", bytes.NewReader(payload))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("content-type", "application/json")
+	req.Header.Set("x-api-key", apiKey)
+	req.Header.Set("anthropic-version", "2023-06-01")
+
+	client := r.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	httpResp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer httpResp.Body.Close()
+	respBody, err := io.ReadAll(httpResp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("messages API returned %d: %s", httpResp.StatusCode, respBody)
+	}
+
+	var resp apiResponse
+	if err := json.Unmarshal(respBody, &resp); err != nil {
+		return nil, fmt.Errorf("parsing API response: %w", err)
+	}
+	if resp.Usage == nil {
+		resp.Usage = &ClaudeUsage{}
+	}
+	return &resp, nil
+}
+
+// applyFileTool executes one emulated tool call against workDir.
+func applyFileTool(workDir, name string, input json.RawMessage) (string, error) {
+	var args struct {
+		Path    string `json:"path"`
+		Content string `json:"content"`
+	}
+	if len(input) > 0 {
+		if err := json.Unmarshal(input, &args); err != nil {
+			return "", fmt.Errorf("bad tool input: %w", err)
+		}
+	}
+
+	switch name {
+	case "read_file":
+		path, err := resolveWorkPath(workDir, args.Path)
+		if err != nil {
+			return "", err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", err
+		}
+		return string(data), nil
+	case "write_file":
+		path, err := resolveWorkPath(workDir, args.Path)
+		if err != nil {
+			return "", err
+		}
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return "", err
+		}
+		if err := os.WriteFile(path, []byte(args.Content), 0o644); err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("wrote %d bytes to %s", len(args.Content), args.Path), nil
+	case "list_files":
+		var files []string
+		err := filepath.WalkDir(workDir, func(path string, d os.DirEntry, err error) error {
+			if err != nil || d.IsDir() {
+				return err
+			}
+			rel, _ := filepath.Rel(workDir, path)
+			files = append(files, filepath.ToSlash(rel))
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+		sort.Strings(files)
+		return strings.Join(files, "\n"), nil
+	default:
+		return "", fmt.Errorf("unknown tool %q", name)
+	}
+}
+
+// resolveWorkPath joins a tool-supplied path onto workDir, refusing
+// anything that escapes it.
+func resolveWorkPath(workDir, rel string) (string, error) {
+	path := filepath.Join(workDir, filepath.FromSlash(rel))
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	root, err := filepath.Abs(workDir)
+	if err != nil {
+		return "", err
+	}
+	if abs != root && !strings.HasPrefix(abs, root+string(filepath.Separator)) {
+		return "", fmt.Errorf("path %q escapes the workdir", rel)
+	}
+	return abs, nil
+}
@@ -0,0 +1,133 @@
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestResolveWorkPath(t *testing.T) {
+	dir := t.TempDir()
+	if _, err := resolveWorkPath(dir, "a/b.txt"); err != nil {
+		t.Errorf("in-tree path rejected: %v", err)
+	}
+	if _, err := resolveWorkPath(dir, "../escape.txt"); err == nil {
+		t.Error("expected traversal to be rejected")
+	}
+	if _, err := resolveWorkPath(dir, "a/../../escape.txt"); err == nil {
+		t.Error("expected nested traversal to be rejected")
+	}
+}
+
+func TestApplyFileTool(t *testing.T) {
+	dir := t.TempDir()
+
+	out, err := applyFileTool(dir, "write_file", json.RawMessage(`{"path":".rwx/ci.yml","content":"tasks: []\n"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out, ".rwx/ci.yml") {
+		t.Errorf("write output = %q", out)
+	}
+
+	out, err = applyFileTool(dir, "read_file", json.RawMessage(`{"path":".rwx/ci.yml"}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != "tasks: []\n" {
+		t.Errorf("read output = %q", out)
+	}
+
+	out, err = applyFileTool(dir, "list_files", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if out != ".rwx/ci.yml" {
+		t.Errorf("list output = %q", out)
+	}
+
+	if _, err := applyFileTool(dir, "delete_file", nil); err == nil {
+		t.Error("expected unknown tool to error")
+	}
+}
+
+func TestAPIRunnerLoop(t *testing.T) {
+	dir := t.TempDir()
+
+	turn := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("x-api-key") != "test-key" {
+			t.Errorf("x-api-key = %q", r.Header.Get("x-api-key"))
+		}
+		turn++
+		var resp apiResponse
+		switch turn {
+		case 1:
+			resp = apiResponse{
+				StopReason: "tool_use",
+				Content: []ContentBlock{{
+					Type: "tool_use", ID: "tu_1", Name: "write_file",
+					Input: json.RawMessage(`{"path":".rwx/ci.yml","content":"tasks: []\n"}`),
+				}},
+				Usage: &ClaudeUsage{InputTokens: 100, OutputTokens: 20},
+			}
+		default:
+			resp = apiResponse{
+				StopReason: "end_turn",
+				Content:    []ContentBlock{{Type: "text", Text: "done"}},
+				Usage:      &ClaudeUsage{InputTokens: 150, OutputTokens: 10},
+			}
+		}
+		json.NewEncoder(w).Encode(resp)
+	}))
+	defer server.Close()
+
+	runner := &APIRunner{Model: "test-model", APIKey: "test-key", BaseURL: server.URL}
+	result, err := runner.Run(context.Background(), "migrate this project", dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if turn != 2 {
+		t.Errorf("server saw %d turns", turn)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".rwx", "ci.yml")); err != nil {
+		t.Errorf("tool call did not write config: %v", err)
+	}
+	if got := result.ToolsUsed(); len(got) != 1 || got[0] != "write_file" {
+		t.Errorf("ToolsUsed = %v", got)
+	}
+	if got := result.TotalTokens(); got != 280 {
+		t.Errorf("TotalTokens = %d", got)
+	}
+	if result.ResultEvent() == nil {
+		t.Error("transcript missing result event")
+	}
+}
+
+func TestAPIRunnerMaxTurns(t *testing.T) {
+	dir := t.TempDir()
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		json.NewEncoder(w).Encode(apiResponse{
+			StopReason: "tool_use",
+			Content:    []ContentBlock{{Type: "tool_use", ID: "tu", Name: "list_files"}},
+			Usage:      &ClaudeUsage{},
+		})
+	}))
+	defer server.Close()
+
+	runner := &APIRunner{Model: "test-model", APIKey: "k", BaseURL: server.URL, MaxTurns: 3}
+	if _, err := runner.Run(context.Background(), "loop forever", dir); err != nil {
+		t.Fatal(err)
+	}
+	if calls != 3 {
+		t.Errorf("expected MaxTurns to cap at 3 calls, got %d", calls)
+	}
+}
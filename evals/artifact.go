@@ -0,0 +1,161 @@
+package evals
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// ArtifactsDir is where transcripts and other per-run results are saved.
+// CI points EVALS_ARTIFACTS_DIR at a task output directory; locally it
+// defaults to evals/artifacts (gitignored).
+func ArtifactsDir() string {
+	if dir := os.Getenv("EVALS_ARTIFACTS_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(evalsPackageDir(), "artifacts")
+}
+
+// evalsPackageDir locates this package's source directory. It is derived
+// from the source file rather than BaselineDir, which EVALS_BASELINES_DIR
+// can point at an entirely different tree.
+func evalsPackageDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}
+
+// PackEvalState bundles the baselines and the results store into a
+// gzipped tarball suitable for upload as a CI artifact, so state survives
+// ephemeral workspaces between runs.
+func PackEvalState(tarball string) error {
+	f, err := os.Create(tarball)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz := gzip.NewWriter(f)
+	defer gz.Close()
+	tw := tar.NewWriter(gz)
+	defer tw.Close()
+
+	for prefix, dir := range map[string]string{
+		"baselines": BaselineDir(),
+		"artifacts": ArtifactsDir(),
+	} {
+		if _, err := os.Stat(dir); os.IsNotExist(err) {
+			continue
+		}
+		if err := addTreeToTar(tw, prefix, dir); err != nil {
+			return fmt.Errorf("packing %s: %w", prefix, err)
+		}
+	}
+	return nil
+}
+
+func addTreeToTar(tw *tar.Writer, prefix, dir string) error {
+	return filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil || info.IsDir() {
+			return err
+		}
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		hdr, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		hdr.Name = prefix + "/" + filepath.ToSlash(rel)
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		_, err = io.Copy(tw, f)
+		return err
+	})
+}
+
+// RestoreEvalState extracts a previously packed state tarball. Baselines
+// that already exist locally are resolved by RecordedAt (newest wins), so
+// a restored artifact never clobbers fresher local state; everything else
+// is overwritten.
+func RestoreEvalState(tarball string) error {
+	f, err := os.Open(tarball)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		prefix, rel, ok := strings.Cut(filepath.ToSlash(hdr.Name), "/")
+		if !ok || strings.Contains(rel, "..") {
+			continue
+		}
+		var root string
+		switch prefix {
+		case "baselines":
+			root = BaselineDir()
+		case "artifacts":
+			root = ArtifactsDir()
+		default:
+			continue
+		}
+		target := filepath.Join(root, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+			return err
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return err
+		}
+		if prefix == "baselines" {
+			if keep, err := localBaselineNewer(target, data); err != nil {
+				return err
+			} else if keep {
+				continue
+			}
+		}
+		if err := os.WriteFile(target, data, 0o644); err != nil {
+			return err
+		}
+	}
+}
+
+// localBaselineNewer reports whether an existing local baseline is newer
+// than the incoming restored copy and should be kept.
+func localBaselineNewer(path string, incoming []byte) (bool, error) {
+	local, err := readBaselineFile(path)
+	if err != nil {
+		return false, nil // no comparable local copy; take the restored one
+	}
+	restored, err := parseBaseline(incoming)
+	if err != nil {
+		return false, fmt.Errorf("restored baseline %s: %w", path, err)
+	}
+	return local.RecordedAt.After(restored.RecordedAt), nil
+}
@@ -0,0 +1,64 @@
+package evals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestPackAndRestoreEvalState(t *testing.T) {
+	baselines := t.TempDir()
+	artifacts := t.TempDir()
+	t.Setenv("EVALS_BASELINES_DIR", baselines)
+	t.Setenv("EVALS_ARTIFACTS_DIR", artifacts)
+
+	old := &Baseline{Eval: "e", TotalTokens: 100, RecordedAt: time.Now().Add(-time.Hour)}
+	if err := SaveBaseline(old); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(artifacts, "transcript.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	tarball := filepath.Join(t.TempDir(), "state.tar.gz")
+	if err := PackEvalState(tarball); err != nil {
+		t.Fatalf("PackEvalState: %v", err)
+	}
+
+	// A newer local baseline must survive restoring the older artifact.
+	newer := &Baseline{Eval: "e", TotalTokens: 200, RecordedAt: time.Now()}
+	if err := SaveBaseline(newer); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(artifacts, "transcript.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := RestoreEvalState(tarball); err != nil {
+		t.Fatalf("RestoreEvalState: %v", err)
+	}
+	got, err := LoadBaseline("e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TotalTokens != 200 {
+		t.Errorf("restore clobbered newer local baseline: tokens = %d", got.TotalTokens)
+	}
+	if _, err := os.Stat(filepath.Join(artifacts, "transcript.json")); err != nil {
+		t.Errorf("transcript not restored: %v", err)
+	}
+
+	// Into an empty workspace the packed baseline is taken as-is.
+	t.Setenv("EVALS_BASELINES_DIR", t.TempDir())
+	if err := RestoreEvalState(tarball); err != nil {
+		t.Fatal(err)
+	}
+	got, err = LoadBaseline("e")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TotalTokens != 100 {
+		t.Errorf("restored baseline tokens = %d, want 100", got.TotalTokens)
+	}
+}
@@ -0,0 +1,1265 @@
+package evals
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/rwx-cloud/skills/rwxconfig"
+)
+
+// TB is the subset of testing.TB that assertions report through. Using an
+// interface lets combinators probe child assertions without failing the
+// real test.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...any)
+	Logf(format string, args ...any)
+}
+
+// ConfigAssertion is a named check against a parsed RWX config. The
+// optional Description explains the invariant in prose; it is rendered
+// alongside failures so CI output is reviewable without decoding names
+// like has_run_go_test.
+type ConfigAssertion struct {
+	Name        string
+	Description string
+	Check       func(t TB, cfg *RWXConfig)
+}
+
+// String renders the assertion for logs and reports: the name, plus the
+// description when one is set.
+func (a ConfigAssertion) String() string {
+	if a.Description == "" {
+		return a.Name
+	}
+	return fmt.Sprintf("%s (%s)", a.Name, a.Description)
+}
+
+// Describe attaches a human-readable description to an assertion built
+// by one of the generic constructors.
+func Describe(description string, a ConfigAssertion) ConfigAssertion {
+	a.Description = description
+	return a
+}
+
+// AssertConfig loads the RWX configs generated under workDir and runs each
+// assertion as a subtest.
+func AssertConfig(t *testing.T, workDir string, assertions ...ConfigAssertion) {
+	t.Helper()
+	cfg, err := LoadRWXConfigs(workDir)
+	if err != nil {
+		t.Fatalf("loading generated configs: %v", err)
+	}
+	span := StartSpan("assertions.evaluate", map[string]string{"test": t.Name()})
+	defer span.End(nil)
+	for _, a := range assertions {
+		a := a
+		if reason, ok := quarantineReason(activeQuarantine(), t.Name(), a.Name); ok {
+			a = quarantined(a, reason)
+		}
+		t.Run(a.Name, func(t *testing.T) {
+			start := time.Now()
+			a.Check(t, cfg)
+			if t.Failed() && a.Description != "" {
+				t.Logf("assertion checks that: %s", a.Description)
+			}
+			recordAssertionTiming(AssertionTiming{
+				Test:        t.Name(),
+				Assertion:   a.Name,
+				Description: a.Description,
+				Duration:    time.Since(start),
+				Failed:      t.Failed(),
+				Status:      statusFor(a.Name, t.Failed()),
+			})
+			recordAssertionOutcome(t.Name(), a.Name, !t.Failed())
+		})
+	}
+}
+
+// probeTB captures failures from a child assertion so combinators can
+// inspect them without failing the enclosing test.
+type probeTB struct {
+	failed   bool
+	messages []string
+}
+
+func (p *probeTB) Helper() {}
+
+func (p *probeTB) Errorf(format string, args ...any) {
+	p.failed = true
+	p.messages = append(p.messages, fmt.Sprintf(format, args...))
+}
+
+func (p *probeTB) Logf(format string, args ...any) {
+	p.messages = append(p.messages, fmt.Sprintf(format, args...))
+}
+
+// Probe runs an assertion against a config outside of a *testing.T,
+// returning its messages and whether it failed. Tooling (e.g. the triage
+// REPL) uses it to re-run invariants against saved configs.
+func Probe(a ConfigAssertion, cfg *RWXConfig) ([]string, bool) {
+	p := &probeTB{}
+	a.Check(p, cfg)
+	return p.messages, p.failed
+}
+
+// Either passes when at least one of the child assertions passes. It is
+// used for invariants with several acceptable shapes, e.g. installing Go
+// via the package or via mise.
+func Either(name string, assertions ...ConfigAssertion) ConfigAssertion {
+	return ConfigAssertion{
+		Name: name,
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			var failures []string
+			for _, a := range assertions {
+				probe := &probeTB{}
+				a.Check(probe, cfg)
+				if !probe.failed {
+					return
+				}
+				failures = append(failures, fmt.Sprintf("%s: %s", a.Name, strings.Join(probe.messages, "; ")))
+			}
+			t.Errorf("no alternative passed:\n  %s", strings.Join(failures, "\n  "))
+		},
+	}
+}
+
+// InFile scopes child assertions to the tasks defined by one config file
+// (workspace-relative, e.g. ".rwx/deploy.yml"), so evals can assert a
+// pipeline lives in its own file.
+func InFile(source string, assertions ...ConfigAssertion) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("in_file_%s", sanitizeName(source)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			scoped := &RWXConfig{On: cfg.On, Base: cfg.Base, Source: source}
+			for _, task := range cfg.Tasks {
+				if task.SourceFile == source {
+					scoped.Tasks = append(scoped.Tasks, task)
+				}
+			}
+			if len(scoped.Tasks) == 0 {
+				t.Errorf("no tasks defined in %q (sources: %v)", source, taskSources(cfg))
+				return
+			}
+			for _, a := range assertions {
+				probe := &probeTB{}
+				a.Check(probe, scoped)
+				if probe.failed {
+					t.Errorf("%s (in %s): %s", a.Name, source, strings.Join(probe.messages, "; "))
+				}
+			}
+		},
+	}
+}
+
+func taskSources(cfg *RWXConfig) []string {
+	var sources []string
+	seen := map[string]bool{}
+	for _, task := range cfg.Tasks {
+		if task.SourceFile != "" && !seen[task.SourceFile] {
+			seen[task.SourceFile] = true
+			sources = append(sources, task.SourceFile)
+		}
+	}
+	return sources
+}
+
+// MinTaskCount asserts the config declares at least n tasks.
+func MinTaskCount(n int) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("min_task_count_%d", n),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			if len(cfg.Tasks) < n {
+				t.Errorf("expected at least %d tasks, got %d (%v)", n, len(cfg.Tasks), cfg.TaskKeys())
+			}
+		},
+	}
+}
+
+// MaxTaskCount bounds over-decomposition: a two-step workflow should
+// not balloon into dozens of tiny tasks.
+func MaxTaskCount(n int) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("max_task_count_%d", n),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			if len(cfg.Tasks) > n {
+				t.Errorf("expected at most %d tasks, got %d (%v)", n, len(cfg.Tasks), cfg.TaskKeys())
+			}
+		},
+	}
+}
+
+// ExactTaskCount pins the task count for evals with a fully specified
+// expected shape.
+func ExactTaskCount(n int) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("exact_task_count_%d", n),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			if len(cfg.Tasks) != n {
+				t.Errorf("expected exactly %d tasks, got %d (%v)", n, len(cfg.Tasks), cfg.TaskKeys())
+			}
+		},
+	}
+}
+
+// TaskDependsOn asserts that the given task lists dep in its use clause.
+func TaskDependsOn(taskKey, dep string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("task_%s_depends_on_%s", taskKey, dep),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			task := cfg.Task(taskKey)
+			if task == nil {
+				t.Errorf("no task %q (have %v)", taskKey, cfg.TaskKeys())
+				return
+			}
+			if !task.Use.Contains(dep) {
+				t.Errorf("task %q does not use %q (use: %v)", taskKey, dep, task.Use)
+			}
+		},
+	}
+}
+
+// TaskOrderedAfter asserts that taskKey can only run after dep, directly
+// or through a chain of intermediate dependencies. Prefer it over
+// hand-rolled TaskDependsOn chains when the exact shape of the graph
+// between the two tasks doesn't matter.
+func TaskOrderedAfter(taskKey, dep string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("task_%s_ordered_after_%s", taskKey, dep),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			task := cfg.Task(taskKey)
+			if task == nil {
+				t.Errorf("no task %q (have %v)", taskKey, cfg.TaskKeys())
+				return
+			}
+			if cfg.Task(dep) == nil {
+				t.Errorf("no task %q (have %v)", dep, cfg.TaskKeys())
+				return
+			}
+			if !dependsTransitively(cfg, taskKey, dep, map[string]bool{}) {
+				t.Errorf("task %q is not ordered after %q: no dependency path between them", taskKey, dep)
+			}
+		},
+	}
+}
+
+func dependsTransitively(cfg *RWXConfig, from, to string, seen map[string]bool) bool {
+	if seen[from] {
+		return false
+	}
+	seen[from] = true
+	task := cfg.Task(from)
+	if task == nil {
+		return false
+	}
+	for _, use := range task.Use {
+		if use == to || dependsTransitively(cfg, use, to, seen) {
+			return true
+		}
+	}
+	return false
+}
+
+// Building blocks for common fixture shapes, shared by the in-repo evals
+// and external consumers of this package. Each toolchain installer
+// accepts rwx/tool-versions as an alternative, since skills may route
+// version selection through a .tool-versions file.
+
+// ClonesRepo asserts the config clones the repository.
+func ClonesRepo() ConfigAssertion {
+	return UsesPackage("git/clone")
+}
+
+// InstallsGo asserts the config installs a Go toolchain.
+func InstallsGo() ConfigAssertion {
+	return installsToolchain("go", "golang/install")
+}
+
+// InstallsNode asserts the config installs a Node toolchain.
+func InstallsNode() ConfigAssertion {
+	return installsToolchain("node", "nodejs/install")
+}
+
+// InstallsRust asserts the config installs a Rust toolchain.
+func InstallsRust() ConfigAssertion {
+	return installsToolchain("rust", "rust/install")
+}
+
+// InstallsPython asserts the config installs a Python toolchain.
+func InstallsPython() ConfigAssertion {
+	return installsToolchain("python", "python/install")
+}
+
+func installsToolchain(toolchain, pkg string) ConfigAssertion {
+	return Either(fmt.Sprintf("installs_%s", toolchain),
+		UsesPackage(pkg),
+		UsesPackage("rwx/tool-versions"),
+	)
+}
+
+// TaskProducesArtifact asserts that the task declares a named artifact in
+// its outputs.
+func TaskProducesArtifact(taskKey, name string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("task_%s_produces_%s", taskKey, sanitizeName(name)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			task := cfg.Task(taskKey)
+			if task == nil {
+				t.Errorf("no task %q (have %v)", taskKey, cfg.TaskKeys())
+				return
+			}
+			if !producesArtifact(task, name) {
+				t.Errorf("task %q does not declare artifact %q in its outputs", taskKey, name)
+			}
+		},
+	}
+}
+
+// TaskConsumesArtifact asserts that the task is wired to a producer of
+// the named artifact through its dependency chain, i.e. the artifact
+// built upstream actually reaches it instead of being rebuilt or
+// ignored.
+func TaskConsumesArtifact(taskKey, name string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("task_%s_consumes_%s", taskKey, sanitizeName(name)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			task := cfg.Task(taskKey)
+			if task == nil {
+				t.Errorf("no task %q (have %v)", taskKey, cfg.TaskKeys())
+				return
+			}
+			var producers []string
+			for i := range cfg.Tasks {
+				if producesArtifact(&cfg.Tasks[i], name) {
+					producers = append(producers, cfg.Tasks[i].Key)
+				}
+			}
+			if len(producers) == 0 {
+				t.Errorf("no task produces artifact %q", name)
+				return
+			}
+			for _, producer := range producers {
+				if dependsTransitively(cfg, taskKey, producer, map[string]bool{}) {
+					return
+				}
+			}
+			t.Errorf("task %q does not use any producer of artifact %q (produced by %v)", taskKey, name, producers)
+		},
+	}
+}
+
+func producesArtifact(task *RWXTask, name string) bool {
+	if task.Outputs == nil {
+		return false
+	}
+	for _, artifact := range task.Outputs.Artifacts {
+		if artifact.Key == name {
+			return true
+		}
+	}
+	return false
+}
+
+// HasTaskGroupForPath asserts that a cohesive group of tasks is scoped
+// to one directory of a monorepo (e.g. "services/api"), either via
+// filter globs under that path or via keys that name it. A global
+// MinTaskCount says nothing about per-service coverage; this does.
+func HasTaskGroupForPath(path string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("has_task_group_for_%s", sanitizeName(path)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			var scoped []RWXTask
+			for _, task := range cfg.Tasks {
+				if taskScopedToPath(task, path) {
+					scoped = append(scoped, task)
+				}
+			}
+			if len(scoped) < 2 {
+				t.Errorf("only %d task(s) scoped to %q; want a cohesive install/test/build group (tasks: %v)",
+					len(scoped), path, cfg.TaskKeys())
+				return
+			}
+			for _, task := range scoped {
+				if testCommandRE.MatchString(task.Run) || stepGroupREs["build"].MatchString(task.Run) {
+					return
+				}
+			}
+			t.Errorf("no task scoped to %q runs tests or builds", path)
+		},
+	}
+}
+
+// taskScopedToPath reports whether a task is tied to a monorepo path by
+// filter or by naming convention.
+func taskScopedToPath(task RWXTask, path string) bool {
+	for _, glob := range task.Filter {
+		if strings.HasPrefix(NormalizeGlob(glob), path+"/") {
+			return true
+		}
+	}
+	segment := path[strings.LastIndex(path, "/")+1:]
+	slug := strings.ReplaceAll(path, "/", "-")
+	return strings.Contains(task.Key, slug) || strings.Contains(task.Key, segment)
+}
+
+// testReportPackageRE matches RWX packages that publish test reports,
+// e.g. rwx/test-results or a framework-specific */test-report package.
+var testReportPackageRE = regexp.MustCompile(`(^|/)test-(results|reports?)$`)
+
+// PublishesTestResults asserts the config publishes test results for the
+// given framework: some task either declares outputs.test-results or
+// calls an RWX test-report package. Migration evals use it to require
+// that JUnit/report publishing from the original workflow is preserved
+// rather than dropped.
+func PublishesTestResults(framework string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("publishes_test_results_%s", sanitizeName(framework)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				if task.Outputs != nil && len(task.Outputs.TestResults) > 0 {
+					return
+				}
+				name, _ := splitCall(task.Call)
+				if testReportPackageRE.MatchString(name) {
+					return
+				}
+			}
+			t.Errorf("no task publishes %s test results: declare outputs.test-results or call a test-report package", framework)
+		},
+	}
+}
+
+// UsesPackage asserts that some task calls the given RWX package.
+func UsesPackage(pkg string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("uses_package_%s", sanitizeName(pkg)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			if len(cfg.TasksCalling(pkg)) == 0 {
+				t.Errorf("no task calls %q", pkg)
+			}
+		},
+	}
+}
+
+// TaskRunContains asserts the given task's run block contains substr.
+func TaskRunContains(taskKey, substr string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("task_%s_runs_%s", taskKey, sanitizeName(substr)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			task := cfg.Task(taskKey)
+			if task == nil {
+				t.Errorf("no task %q (have %v)", taskKey, cfg.TaskKeys())
+				return
+			}
+			if !strings.Contains(task.Run, substr) {
+				t.Errorf("task %q run block does not contain %q:\n%s", taskKey, substr, task.Run)
+			}
+		},
+	}
+}
+
+// AnyTaskRunContains asserts that some task's run block contains substr.
+func AnyTaskRunContains(substr string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("any_task_runs_%s", sanitizeName(substr)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				if strings.Contains(task.Run, substr) {
+					return
+				}
+			}
+			t.Errorf("no task run block contains %q", substr)
+		},
+	}
+}
+
+// EnvVarSet asserts that some task sets the given environment variable.
+func EnvVarSet(key string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("env_var_set_%s", sanitizeName(key)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				if _, ok := task.Env[key]; ok {
+					return
+				}
+			}
+			t.Errorf("no task sets env var %q", key)
+		},
+	}
+}
+
+// EnvVarEquals asserts some task sets the env var to exactly the wanted
+// value.
+func EnvVarEquals(key, want string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("env_var_%s_equals", sanitizeName(key)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			checkEnvVar(t, cfg, key, func(got string) bool {
+				return got == want
+			}, fmt.Sprintf("= %q", want))
+		},
+	}
+}
+
+// EnvVarMatches is EnvVarEquals with a regexp, for checking e.g. that
+// DATABASE_URL points at the background-process hostname the config
+// actually starts.
+func EnvVarMatches(key, pattern string) ConfigAssertion {
+	re := regexp.MustCompile(pattern)
+	return ConfigAssertion{
+		Name: fmt.Sprintf("env_var_%s_matches", sanitizeName(key)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			checkEnvVar(t, cfg, key, re.MatchString, fmt.Sprintf("matching %q", pattern))
+		},
+	}
+}
+
+// checkEnvVar checks every task (and background process) setting the
+// env var against ok, logging the values that missed.
+func checkEnvVar(t TB, cfg *RWXConfig, key string, ok func(string) bool, want string) {
+	t.Helper()
+	found := false
+	for _, task := range cfg.Tasks {
+		if got, present := task.Env[key]; present {
+			if ok(got) {
+				return
+			}
+			found = true
+			t.Logf("task %q sets %s=%q", task.Key, key, got)
+		}
+		for _, bg := range task.BackgroundProcesses {
+			if got, present := bg.Env[key]; present {
+				if ok(got) {
+					return
+				}
+				found = true
+				t.Logf("background process %q sets %s=%q", bg.Key, key, got)
+			}
+		}
+	}
+	if found {
+		t.Errorf("no task sets %s %s", key, want)
+	} else {
+		t.Errorf("no task sets env var %q", key)
+	}
+}
+
+// HasBackgroundProcess asserts that some task declares a background
+// process whose key contains substr.
+func HasBackgroundProcess(substr string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("has_background_process_%s", sanitizeName(substr)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				for _, bg := range task.BackgroundProcesses {
+					if strings.Contains(bg.Key, substr) {
+						return
+					}
+				}
+			}
+			t.Errorf("no background process matching %q", substr)
+		},
+	}
+}
+
+// ghaWorkflowCommands are GitHub Actions workflow-command and output
+// idioms that do nothing on RWX and frequently survive naive migrations.
+var ghaWorkflowCommands = []string{
+	"::error",
+	"::warning",
+	"::notice",
+	"::set-output",
+	"::add-mask",
+	"::group",
+	"$GITHUB_OUTPUT",
+	"$GITHUB_ENV",
+	"$GITHUB_STEP_SUMMARY",
+	"$GITHUB_PATH",
+}
+
+// HasProblemMatcherFreeOutput asserts that no run block still uses GHA
+// workflow-command syntax or $GITHUB_* output files; migrated configs
+// should use RWX-native output mechanisms instead.
+func HasProblemMatcherFreeOutput() ConfigAssertion {
+	return ConfigAssertion{
+		Name: "has_problem_matcher_free_output",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				for _, cmd := range ghaWorkflowCommands {
+					if strings.Contains(task.Run, cmd) {
+						t.Errorf("task %q run block still uses GHA syntax %q:\n%s", task.Key, cmd, task.Run)
+					}
+				}
+			}
+		},
+	}
+}
+
+// unpinnedInstallPatterns match supply-chain-risky install idioms in run
+// blocks: piping a download straight into a shell, or installing a Go
+// tool at a floating version.
+var unpinnedInstallPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?:curl|wget)[^|\n]*\|\s*(?:sudo\s+)?(?:ba|z)?sh`),
+	regexp.MustCompile(`go\s+install\s+\S+@(?:latest|master|main)`),
+}
+
+// HasNoUnpinnedCurlInstalls asserts no run block installs software via
+// curl-pipe-to-shell or floating go install versions; generated configs
+// should use RWX packages or pinned versions instead.
+func HasNoUnpinnedCurlInstalls() ConfigAssertion {
+	return ConfigAssertion{
+		Name: "has_no_unpinned_curl_installs",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				for _, re := range unpinnedInstallPatterns {
+					if match := re.FindString(task.Run); match != "" {
+						t.Errorf("task %q uses an unpinned install: %q", task.Key, match)
+					}
+				}
+			}
+		},
+	}
+}
+
+// TaskHasFilter asserts the given task declares a content filter matching
+// glob. Globs are normalized before comparison so `./services/api/**` and
+// `services/api/**` are treated as the same filter.
+func TaskHasFilter(taskKey, glob string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("task_%s_has_filter_%s", taskKey, sanitizeName(glob)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			task := cfg.Task(taskKey)
+			if task == nil {
+				t.Errorf("no task %q (have %v)", taskKey, cfg.TaskKeys())
+				return
+			}
+			want := NormalizeGlob(glob)
+			for _, f := range task.Filter {
+				if NormalizeGlob(f) == want {
+					return
+				}
+			}
+			t.Errorf("task %q has no filter %q (filters: %v)", taskKey, glob, task.Filter)
+		},
+	}
+}
+
+// AllTasksHaveFilters asserts every task with a run block declares a
+// content filter, so unrelated changes don't bust its cache. Call-only
+// tasks (clone, installs) are exempt since their inputs are their with
+// params.
+func AllTasksHaveFilters() ConfigAssertion {
+	return ConfigAssertion{
+		Name: "all_tasks_have_filters",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			var missing []string
+			for _, task := range cfg.Tasks {
+				if task.Run != "" && len(task.Filter) == 0 {
+					missing = append(missing, task.Key)
+				}
+			}
+			if len(missing) > 0 {
+				t.Errorf("tasks without filters: %v", missing)
+			}
+		},
+	}
+}
+
+// TaskHasTimeout asserts the given task declares a timeout, optionally
+// bounded: maxMinutes 0 accepts any timeout.
+func TaskHasTimeout(taskKey string, maxMinutes int) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("task_%s_has_timeout", taskKey),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			task := cfg.Task(taskKey)
+			if task == nil {
+				t.Errorf("no task %q (have %v)", taskKey, cfg.TaskKeys())
+				return
+			}
+			if task.TimeoutMinutes == 0 {
+				t.Errorf("task %q declares no timeout", taskKey)
+				return
+			}
+			if maxMinutes > 0 && task.TimeoutMinutes > maxMinutes {
+				t.Errorf("task %q timeout is %d minutes, want at most %d", taskKey, task.TimeoutMinutes, maxMinutes)
+			}
+		},
+	}
+}
+
+// TaskHasRetries asserts the given task declares retries, so known-flaky
+// steps don't fail the whole run on the first attempt.
+func TaskHasRetries(taskKey string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("task_%s_has_retries", taskKey),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			task := cfg.Task(taskKey)
+			if task == nil {
+				t.Errorf("no task %q (have %v)", taskKey, cfg.TaskKeys())
+				return
+			}
+			if task.Retries == 0 {
+				t.Errorf("task %q declares no retries", taskKey)
+			}
+		},
+	}
+}
+
+// envPortRE extracts a port from an env var value like a DATABASE_URL
+// or host:port pair.
+var envPortRE = regexp.MustCompile(`:(\d{2,5})(?:/|$|\?)`)
+
+// ServiceListensOn asserts that a background process matching service
+// exposes the given port, and that every env var referencing the service
+// points at a port it actually exposes — catching configs where
+// DATABASE_URL names a port no service listens on.
+func ServiceListensOn(service string, port int) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("service_%s_listens_on_%d", sanitizeName(service), port),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			exposed := map[int]bool{}
+			found := false
+			for _, task := range cfg.Tasks {
+				for _, bg := range task.BackgroundProcesses {
+					if !strings.Contains(bg.Key, service) {
+						continue
+					}
+					found = true
+					for _, p := range bg.Ports {
+						exposed[p] = true
+					}
+				}
+			}
+			if !found {
+				t.Errorf("no background process matching %q", service)
+				return
+			}
+			if !exposed[port] {
+				t.Errorf("service %q does not expose port %d (ports: %v)", service, port, sortedPorts(exposed))
+			}
+			for _, task := range cfg.Tasks {
+				for key, value := range task.Env {
+					if !strings.Contains(value, service) {
+						continue
+					}
+					m := envPortRE.FindStringSubmatch(value)
+					if m == nil {
+						continue
+					}
+					if p, _ := strconv.Atoi(m[1]); !exposed[p] {
+						t.Errorf("task %q env %s=%q references port %d, which %q does not expose (ports: %v)",
+							task.Key, key, value, p, service, sortedPorts(exposed))
+					}
+				}
+			}
+		},
+	}
+}
+
+func sortedPorts(ports map[int]bool) []int {
+	out := make([]int, 0, len(ports))
+	for p := range ports {
+		out = append(out, p)
+	}
+	sort.Ints(out)
+	return out
+}
+
+// BackgroundProcessHasReadyCheck asserts that the background process
+// whose key contains substr declares a ready-check, so service configs
+// wait on readiness instead of sleeping and hoping.
+func BackgroundProcessHasReadyCheck(substr string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("background_process_%s_has_ready_check", sanitizeName(substr)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			found := false
+			for _, task := range cfg.Tasks {
+				for _, bg := range task.BackgroundProcesses {
+					if !strings.Contains(bg.Key, substr) {
+						continue
+					}
+					found = true
+					if bg.ReadyCheck == "" {
+						t.Errorf("background process %q (task %q) has no ready-check", bg.Key, task.Key)
+					}
+				}
+			}
+			if !found {
+				t.Errorf("no background process matching %q", substr)
+			}
+		},
+	}
+}
+
+// ConfigAssertionFunc wraps a plain error-returning check as a
+// ConfigAssertion, for one-off invariants that don't warrant the full
+// TB plumbing.
+func ConfigAssertionFunc(name string, fn func(cfg *RWXConfig) error) ConfigAssertion {
+	return ConfigAssertion{
+		Name: name,
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			if err := fn(cfg); err != nil {
+				t.Errorf("%v", err)
+			}
+		},
+	}
+}
+
+// TaskAssertion runs an error-returning check against every task and
+// reports each task that fails it.
+func TaskAssertion(name string, fn func(task RWXTask) error) ConfigAssertion {
+	return ConfigAssertion{
+		Name: name,
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				if err := fn(task); err != nil {
+					t.Errorf("task %q: %v", task.Key, err)
+				}
+			}
+		},
+	}
+}
+
+// HasWithValue asserts some task calling the package passes exactly the
+// wanted value for a with: parameter.
+func HasWithValue(callPrefix, param, want string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("with_%s_%s", sanitizeName(callPrefix), sanitizeName(param)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			checkWithParam(t, cfg, callPrefix, param, func(got string) bool {
+				return got == want
+			}, fmt.Sprintf("= %q", want))
+		},
+	}
+}
+
+// HasWithMatching is HasWithValue with a regexp match instead of
+// equality, for values like init.commit-sha expressions.
+func HasWithMatching(callPrefix, param, pattern string) ConfigAssertion {
+	re := regexp.MustCompile(pattern)
+	return ConfigAssertion{
+		Name: fmt.Sprintf("with_%s_%s_matches", sanitizeName(callPrefix), sanitizeName(param)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			checkWithParam(t, cfg, callPrefix, param, re.MatchString, fmt.Sprintf("matching %q", pattern))
+		},
+	}
+}
+
+// checkWithParam finds tasks calling the package and checks the with:
+// parameter against ok, logging near misses for triage.
+func checkWithParam(t TB, cfg *RWXConfig, callPrefix, param string, ok func(string) bool, want string) {
+	t.Helper()
+	tasks := cfg.TasksCalling(callPrefix)
+	if len(tasks) == 0 {
+		t.Errorf("no task calls %q", callPrefix)
+		return
+	}
+	for _, task := range tasks {
+		got, present := task.With[param]
+		if present && ok(got) {
+			return
+		}
+		if present {
+			t.Logf("task %q passes %s=%q", task.Key, param, got)
+		} else {
+			t.Logf("task %q does not pass %s", task.Key, param)
+		}
+	}
+	t.Errorf("no call to %s passes %s %s", callPrefix, param, want)
+}
+
+// TaskPredicate is a named check scoped to a single task, composed via
+// ForTask.
+type TaskPredicate struct {
+	Name  string
+	Check func(t TB, task *RWXTask)
+}
+
+// ForTask scopes child predicates to one task, expressing invariants
+// like "the test task specifically must set DATABASE_URL". It fails
+// once if the task is missing, and otherwise reports every failing
+// predicate.
+func ForTask(taskKey string, predicates ...TaskPredicate) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("for_task_%s", sanitizeName(taskKey)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			task := cfg.Task(taskKey)
+			if task == nil {
+				t.Errorf("no task %q (have %v)", taskKey, cfg.TaskKeys())
+				return
+			}
+			for _, p := range predicates {
+				probe := &probeTB{}
+				p.Check(probe, task)
+				if probe.failed {
+					t.Errorf("task %q: %s: %s", taskKey, p.Name, strings.Join(probe.messages, "; "))
+				}
+			}
+		},
+	}
+}
+
+// RunContains is a task predicate requiring the run block to contain
+// the substring.
+func RunContains(substr string) TaskPredicate {
+	return TaskPredicate{
+		Name: fmt.Sprintf("run_contains_%s", sanitizeName(substr)),
+		Check: func(t TB, task *RWXTask) {
+			if !strings.Contains(task.Run, substr) {
+				t.Errorf("run does not contain %q", substr)
+			}
+		},
+	}
+}
+
+// SetsEnv is a task predicate requiring the env var to be set.
+func SetsEnv(key string) TaskPredicate {
+	return TaskPredicate{
+		Name: fmt.Sprintf("sets_env_%s", sanitizeName(key)),
+		Check: func(t TB, task *RWXTask) {
+			if _, ok := task.Env[key]; !ok {
+				t.Errorf("env %q not set (has %v)", key, task.Env)
+			}
+		},
+	}
+}
+
+// FiltersOn is a task predicate requiring a filter matching the glob
+// after normalization.
+func FiltersOn(glob string) TaskPredicate {
+	return TaskPredicate{
+		Name: fmt.Sprintf("filters_on_%s", sanitizeName(glob)),
+		Check: func(t TB, task *RWXTask) {
+			want := NormalizeGlob(glob)
+			for _, f := range task.Filter {
+				if NormalizeGlob(f) == want {
+					return
+				}
+			}
+			t.Errorf("no filter matching %q (has %v)", glob, task.Filter)
+		},
+	}
+}
+
+// DependsOn is a task predicate requiring the task to use the given
+// dependency.
+func DependsOn(dep string) TaskPredicate {
+	return TaskPredicate{
+		Name: fmt.Sprintf("depends_on_%s", sanitizeName(dep)),
+		Check: func(t TB, task *RWXTask) {
+			if !task.Use.Contains(dep) {
+				t.Errorf("does not use %q (uses %v)", dep, task.Use)
+			}
+		},
+	}
+}
+
+// All passes only when every child assertion passes, reporting each
+// failing child rather than stopping at the first. It complements
+// Either for composing helper bundles into reusable invariants.
+func All(name string, assertions ...ConfigAssertion) ConfigAssertion {
+	return ConfigAssertion{
+		Name: name,
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, a := range assertions {
+				if messages, failed := Probe(a, cfg); failed {
+					t.Errorf("%s: %s", a.Name, strings.Join(messages, "; "))
+				}
+			}
+		},
+	}
+}
+
+// Not inverts an assertion: it passes when the wrapped assertion fails,
+// expressing invariants like "does not install Node in a pure Go
+// project". The wrapped assertion runs against a probe so its failures
+// never reach the real test.
+func Not(a ConfigAssertion) ConfigAssertion {
+	return ConfigAssertion{
+		Name: "not_" + a.Name,
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			if _, failed := Probe(a, cfg); !failed {
+				t.Errorf("%s passed, but was expected to fail", a.Name)
+			}
+		},
+	}
+}
+
+// notificationMatchers recognizes the ways a config can send a
+// notification of a given kind: a dedicated package call or an inline
+// webhook/mail invocation.
+var notificationMatchers = map[string]func(RWXTask) bool{
+	"slack": func(task RWXTask) bool {
+		name, _ := splitCall(task.Call)
+		return strings.HasPrefix(name, "slack/") || strings.Contains(task.Run, "hooks.slack.com")
+	},
+	"email": func(task RWXTask) bool {
+		name, _ := splitCall(task.Call)
+		return strings.HasPrefix(name, "email/") ||
+			regexp.MustCompile(`\b(?:sendmail|mail -s|sendgrid)\b`).MatchString(task.Run)
+	},
+	"webhook": func(task RWXTask) bool {
+		return regexp.MustCompile(`\bcurl\b[^\n]*(?:-X *POST|--data|-d )`).MatchString(task.Run)
+	},
+}
+
+// HasNotification asserts some task sends a notification of the given
+// kind (slack, email, or webhook), so CD migrations don't silently drop
+// the failure notifications the source workflow had.
+func HasNotification(kind string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("has_%s_notification", sanitizeName(kind)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			matches, known := notificationMatchers[kind]
+			if !known {
+				t.Errorf("unknown notification kind %q", kind)
+				return
+			}
+			for _, task := range cfg.Tasks {
+				if matches(task) {
+					return
+				}
+			}
+			t.Errorf("no task sends a %s notification", kind)
+		},
+	}
+}
+
+// HasConcurrencyControl asserts the config declares at least one
+// concurrency pool, needed when the source pipeline serialized runs
+// (e.g. a GHA `concurrency:` group on a deploy workflow).
+func HasConcurrencyControl() ConfigAssertion {
+	return ConfigAssertion{
+		Name: "has_concurrency_control",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			if len(cfg.ConcurrencyPools) == 0 {
+				t.Errorf("no concurrency-pools declared")
+				return
+			}
+			for _, pool := range cfg.ConcurrencyPools {
+				if pool.ID == "" {
+					t.Errorf("concurrency pool without an id: %+v", pool)
+				}
+			}
+		},
+	}
+}
+
+// NoOrphanTasks flags dead tasks agents sometimes leave behind: setup
+// tasks (call-only) that no other task uses, and tasks with neither run
+// nor call content. Run tasks with no dependents are legitimate
+// terminal leaves and are not flagged.
+func NoOrphanTasks() ConfigAssertion {
+	return ConfigAssertion{
+		Name: "no_orphan_tasks",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			used := map[string]bool{}
+			for _, task := range cfg.Tasks {
+				for _, dep := range task.Use {
+					used[dep] = true
+				}
+			}
+			for _, task := range cfg.Tasks {
+				switch {
+				case task.Run == "" && task.Call == "":
+					t.Errorf("task %q has neither run nor call content", task.Key)
+				case task.Run == "" && !used[task.Key]:
+					t.Errorf("task %q (%s) is not used by any task", task.Key, task.Call)
+				}
+			}
+		},
+	}
+}
+
+// AllCallsVersionPinned asserts every task call carries an explicit
+// version, so runs don't drift when packages release.
+func AllCallsVersionPinned() ConfigAssertion {
+	return ConfigAssertion{
+		Name: "all_calls_version_pinned",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				if task.Call == "" {
+					continue
+				}
+				if _, version := splitCall(task.Call); version == "" {
+					t.Errorf("task %q calls %q without a pinned version", task.Key, task.Call)
+				}
+			}
+		},
+	}
+}
+
+// PackageVersionAtLeast asserts every call to the package is pinned at
+// or above the given version, so skills don't emit stale majors.
+func PackageVersionAtLeast(pkg, min string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("package_%s_at_least_%s", sanitizeName(pkg), sanitizeName(min)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			tasks := cfg.TasksCalling(pkg)
+			if len(tasks) == 0 {
+				t.Errorf("no task calls %q", pkg)
+				return
+			}
+			for _, task := range tasks {
+				_, version := splitCall(task.Call)
+				if version == "" {
+					t.Errorf("task %q calls %s without a pinned version", task.Key, pkg)
+					continue
+				}
+				if compareVersions(version, min) < 0 {
+					t.Errorf("task %q pins %s %s, below minimum %s", task.Key, pkg, version, min)
+				}
+			}
+		},
+	}
+}
+
+// compareVersions compares dotted numeric versions, returning -1, 0, or
+// 1. Missing components count as zero; non-numeric components compare
+// lexically so prerelease-ish strings still order deterministically.
+func compareVersions(a, b string) int {
+	as, bs := strings.Split(a, "."), strings.Split(b, ".")
+	for i := 0; i < len(as) || i < len(bs); i++ {
+		ap, bp := "0", "0"
+		if i < len(as) {
+			ap = as[i]
+		}
+		if i < len(bs) {
+			bp = bs[i]
+		}
+		an, aerr := strconv.Atoi(ap)
+		bn, berr := strconv.Atoi(bp)
+		switch {
+		case aerr == nil && berr == nil:
+			if an != bn {
+				if an < bn {
+					return -1
+				}
+				return 1
+			}
+		default:
+			if ap != bp {
+				if ap < bp {
+					return -1
+				}
+				return 1
+			}
+		}
+	}
+	return 0
+}
+
+// MatchesRWXSchema asserts the generated config validates against the
+// vendored run-definition schema, catching structural mistakes (GHA-isms,
+// mistyped fields) even where the rwx CLI is unavailable.
+func MatchesRWXSchema() ConfigAssertion {
+	return ConfigAssertion{
+		Name: "matches_rwx_schema",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			violations, err := rwxconfig.ValidateAgainstSchema(cfg)
+			if err != nil {
+				t.Errorf("schema validation: %v", err)
+				return
+			}
+			for _, v := range violations {
+				t.Errorf("schema violation at %s", v)
+			}
+		},
+	}
+}
+
+// HasParallelValues asserts that some task declares parallelism over the
+// given key covering every listed value, in either the single-key or
+// matrix form.
+func HasParallelValues(key string, values ...string) ConfigAssertion {
+	return ConfigAssertion{
+		Name: fmt.Sprintf("has_parallel_values_%s", sanitizeName(key)),
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				declared := task.Parallel.ValuesFor(key)
+				if declared == nil {
+					continue
+				}
+				missing := missingValues(declared, values)
+				if len(missing) == 0 {
+					return
+				}
+				t.Logf("task %q parallelizes %q but is missing %v (has %v)", task.Key, key, missing, declared)
+			}
+			t.Errorf("no task parallelizes %q over all of %v", key, values)
+		},
+	}
+}
+
+func missingValues(declared, want []string) []string {
+	var missing []string
+	for _, w := range want {
+		found := false
+		for _, d := range declared {
+			if d == w {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, w)
+		}
+	}
+	return missing
+}
+
+func sanitizeName(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		default:
+			return '_'
+		}
+	}, s)
+}
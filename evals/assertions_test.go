@@ -0,0 +1,693 @@
+package evals
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func mustParse(t *testing.T, src string) *RWXConfig {
+	t.Helper()
+	cfg, err := ParseRWXConfig([]byte(src))
+	if err != nil {
+		t.Fatalf("parsing config: %v", err)
+	}
+	return cfg
+}
+
+func runAssertion(a ConfigAssertion, cfg *RWXConfig) *probeTB {
+	probe := &probeTB{}
+	a.Check(probe, cfg)
+	return probe
+}
+
+func TestMinTaskCount(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(MinTaskCount(3), cfg); probe.failed {
+		t.Errorf("MinTaskCount(3) failed: %v", probe.messages)
+	}
+	if probe := runAssertion(MinTaskCount(4), cfg); !probe.failed {
+		t.Error("MinTaskCount(4) should fail")
+	}
+}
+
+func TestDescribe(t *testing.T) {
+	a := UsesPackage("golang/install")
+	if got := a.String(); got != a.Name {
+		t.Errorf("String() without description = %q, want %q", got, a.Name)
+	}
+	described := Describe("Go is installed via the official package", a)
+	want := "uses_package_golang_install (Go is installed via the official package)"
+	if got := described.String(); got != want {
+		t.Errorf("String() = %q, want %q", got, want)
+	}
+	if probe := runAssertion(described, mustParse(t, sampleConfig)); probe.failed {
+		t.Errorf("Describe should not change check behavior: %v", probe.messages)
+	}
+}
+
+func TestTaskOrderedAfter(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: install
+    call: golang/install 1.2.0
+  - key: build
+    use: install
+    run: go build ./...
+  - key: deploy
+    use: build
+    run: ./deploy.sh
+  - key: lint
+    run: golangci-lint run
+`)
+	if probe := runAssertion(TaskOrderedAfter("deploy", "build"), cfg); probe.failed {
+		t.Errorf("direct dependency failed: %v", probe.messages)
+	}
+	if probe := runAssertion(TaskOrderedAfter("deploy", "install"), cfg); probe.failed {
+		t.Errorf("transitive dependency failed: %v", probe.messages)
+	}
+	if probe := runAssertion(TaskOrderedAfter("deploy", "lint"), cfg); !probe.failed {
+		t.Error("unrelated tasks should fail")
+	}
+	if probe := runAssertion(TaskOrderedAfter("install", "deploy"), cfg); !probe.failed {
+		t.Error("reversed direction should fail")
+	}
+	if probe := runAssertion(TaskOrderedAfter("deploy", "missing"), cfg); !probe.failed {
+		t.Error("missing dependency task should fail")
+	}
+}
+
+func TestToolchainBundles(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(ClonesRepo(), cfg); probe.failed {
+		t.Errorf("ClonesRepo failed: %v", probe.messages)
+	}
+	if probe := runAssertion(InstallsGo(), cfg); probe.failed {
+		t.Errorf("InstallsGo failed: %v", probe.messages)
+	}
+	for _, a := range []ConfigAssertion{InstallsNode(), InstallsRust(), InstallsPython()} {
+		if probe := runAssertion(a, cfg); !probe.failed {
+			t.Errorf("%s should fail against the Go sample", a.Name)
+		}
+	}
+
+	viaToolVersions := mustParse(t, `
+tasks:
+  - key: tools
+    call: rwx/tool-versions 1.0.1
+`)
+	if probe := runAssertion(InstallsGo(), viaToolVersions); probe.failed {
+		t.Errorf("rwx/tool-versions should satisfy InstallsGo: %v", probe.messages)
+	}
+}
+
+func TestServiceListensOn(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: test
+    run: go test ./...
+    env:
+      DATABASE_URL: postgres://postgres@localhost:5432/app?sslmode=disable
+      REDIS_URL: redis://localhost:6380/0
+    background-processes:
+      - key: postgres
+        run: postgres -D /var/lib/postgresql/data
+        ports: [5432]
+        ready-check: pg_isready
+      - key: redis
+        run: redis-server
+        ports: [6379]
+        ready-check: redis-cli ping
+`)
+	if probe := runAssertion(ServiceListensOn("postgres", 5432), cfg); probe.failed {
+		t.Errorf("postgres listens on 5432: %v", probe.messages)
+	}
+	if probe := runAssertion(ServiceListensOn("postgres", 5433), cfg); !probe.failed {
+		t.Error("postgres does not expose 5433")
+	}
+	probe := runAssertion(ServiceListensOn("redis", 6379), cfg)
+	if !probe.failed {
+		t.Error("REDIS_URL points at 6380, which redis does not expose")
+	}
+	if len(probe.messages) == 0 || !strings.Contains(probe.messages[0], "6380") {
+		t.Errorf("failure should name the mismatched port: %v", probe.messages)
+	}
+	if probe := runAssertion(ServiceListensOn("mysql", 3306), cfg); !probe.failed {
+		t.Error("no mysql background process")
+	}
+}
+
+func TestHasTaskGroupForPath(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: api-install
+    call: nodejs/install 1.1.2
+  - key: api-test
+    use: api-install
+    run: npm test
+    filter:
+      - services/api/**
+  - key: web-test
+    run: npm test
+    filter:
+      - services/web/**
+`)
+	if probe := runAssertion(HasTaskGroupForPath("services/api"), cfg); probe.failed {
+		t.Errorf("services/api group failed: %v", probe.messages)
+	}
+	if probe := runAssertion(HasTaskGroupForPath("services/web"), cfg); !probe.failed {
+		t.Error("services/web has a lone test task, not a group")
+	}
+	if probe := runAssertion(HasTaskGroupForPath("services/worker"), cfg); !probe.failed {
+		t.Error("services/worker has no scoped tasks")
+	}
+}
+
+func TestArtifactFlow(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: build
+    run: go build -o bin/app ./cmd/app
+    outputs:
+      artifacts:
+        - key: app-binary
+          paths: bin/app
+  - key: deploy
+    use: build
+    run: ./scripts/deploy.sh bin/app
+  - key: docs
+    run: mkdocs build
+`)
+	if probe := runAssertion(TaskProducesArtifact("build", "app-binary"), cfg); probe.failed {
+		t.Errorf("TaskProducesArtifact failed: %v", probe.messages)
+	}
+	if probe := runAssertion(TaskProducesArtifact("deploy", "app-binary"), cfg); !probe.failed {
+		t.Error("deploy does not produce the artifact")
+	}
+	if probe := runAssertion(TaskConsumesArtifact("deploy", "app-binary"), cfg); probe.failed {
+		t.Errorf("TaskConsumesArtifact failed: %v", probe.messages)
+	}
+	if probe := runAssertion(TaskConsumesArtifact("docs", "app-binary"), cfg); !probe.failed {
+		t.Error("docs is not wired to the producer")
+	}
+	if probe := runAssertion(TaskConsumesArtifact("deploy", "missing"), cfg); !probe.failed {
+		t.Error("unknown artifact should fail")
+	}
+}
+
+func TestPublishesTestResults(t *testing.T) {
+	withOutputs := mustParse(t, `
+tasks:
+  - key: test
+    run: go test ./... 2>&1 | go-junit-report > junit.xml
+    outputs:
+      test-results:
+        - path: junit.xml
+`)
+	if probe := runAssertion(PublishesTestResults("junit"), withOutputs); probe.failed {
+		t.Errorf("outputs.test-results failed: %v", probe.messages)
+	}
+
+	withPackage := mustParse(t, `
+tasks:
+  - key: report
+    call: rwx/test-results 1.0.0
+    with:
+      path: junit.xml
+`)
+	if probe := runAssertion(PublishesTestResults("junit"), withPackage); probe.failed {
+		t.Errorf("test-results package failed: %v", probe.messages)
+	}
+
+	if probe := runAssertion(PublishesTestResults("junit"), mustParse(t, sampleConfig)); !probe.failed {
+		t.Error("config without test results should fail")
+	}
+}
+
+func TestMaxAndExactTaskCount(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(MaxTaskCount(3), cfg); probe.failed {
+		t.Errorf("MaxTaskCount(3) failed: %v", probe.messages)
+	}
+	if probe := runAssertion(MaxTaskCount(2), cfg); !probe.failed {
+		t.Error("MaxTaskCount(2) should fail")
+	}
+	if probe := runAssertion(ExactTaskCount(3), cfg); probe.failed {
+		t.Errorf("ExactTaskCount(3) failed: %v", probe.messages)
+	}
+	if probe := runAssertion(ExactTaskCount(4), cfg); !probe.failed {
+		t.Error("ExactTaskCount(4) should fail")
+	}
+}
+
+func TestTaskDependsOn(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(TaskDependsOn("test", "go"), cfg); probe.failed {
+		t.Errorf("TaskDependsOn failed: %v", probe.messages)
+	}
+	if probe := runAssertion(TaskDependsOn("test", "missing"), cfg); !probe.failed {
+		t.Error("expected failure for missing dep")
+	}
+	if probe := runAssertion(TaskDependsOn("nope", "go"), cfg); !probe.failed {
+		t.Error("expected failure for missing task")
+	}
+}
+
+func TestEither(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	ok := Either("go_installed", UsesPackage("mise/install"), UsesPackage("golang/install"))
+	if probe := runAssertion(ok, cfg); probe.failed {
+		t.Errorf("Either failed: %v", probe.messages)
+	}
+	bad := Either("nope", UsesPackage("ruby/install"), UsesPackage("python/install"))
+	probe := runAssertion(bad, cfg)
+	if !probe.failed {
+		t.Fatal("Either should fail when no alternative passes")
+	}
+	if !strings.Contains(probe.messages[0], "ruby/install") {
+		t.Errorf("aggregated failure should mention each alternative: %v", probe.messages)
+	}
+}
+
+func TestTaskHasFilter(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: api-test
+    run: go test ./...
+    filter:
+      - ./services/api/**
+  - key: code
+    call: git/clone 2.0.3
+`)
+	if probe := runAssertion(TaskHasFilter("api-test", "services/api/**"), cfg); probe.failed {
+		t.Errorf("normalized filter should match: %v", probe.messages)
+	}
+	if probe := runAssertion(TaskHasFilter("api-test", "services/web/**"), cfg); !probe.failed {
+		t.Error("expected failure for unrelated glob")
+	}
+	if probe := runAssertion(AllTasksHaveFilters(), cfg); probe.failed {
+		t.Errorf("call-only tasks are exempt: %v", probe.messages)
+	}
+
+	cfg.Tasks = append(cfg.Tasks, RWXTask{Key: "web-test", Run: "npm test"})
+	if probe := runAssertion(AllTasksHaveFilters(), cfg); !probe.failed {
+		t.Error("expected failure for unfiltered run task")
+	}
+}
+
+func TestTimeoutAndRetryAssertions(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: e2e
+    run: npm run e2e
+    timeout-minutes: 30
+    retries: 2
+  - key: build
+    run: go build ./...
+`)
+	if got := cfg.Task("e2e").TimeoutMinutes; got != 30 {
+		t.Errorf("timeout-minutes = %d", got)
+	}
+	if got := cfg.Task("e2e").Retries; got != 2 {
+		t.Errorf("retries = %d", got)
+	}
+
+	if probe := runAssertion(TaskHasTimeout("e2e", 0), cfg); probe.failed {
+		t.Errorf("TaskHasTimeout failed: %v", probe.messages)
+	}
+	if probe := runAssertion(TaskHasTimeout("e2e", 15), cfg); !probe.failed {
+		t.Error("expected failure: timeout above bound")
+	}
+	if probe := runAssertion(TaskHasTimeout("build", 0), cfg); !probe.failed {
+		t.Error("expected failure: no timeout declared")
+	}
+	if probe := runAssertion(TaskHasRetries("e2e"), cfg); probe.failed {
+		t.Errorf("TaskHasRetries failed: %v", probe.messages)
+	}
+	if probe := runAssertion(TaskHasRetries("build"), cfg); !probe.failed {
+		t.Error("expected failure: no retries declared")
+	}
+}
+
+func TestHasNoUnpinnedCurlInstalls(t *testing.T) {
+	clean := mustParse(t, `
+tasks:
+  - key: tools
+    run: |
+      go install golang.org/x/vuln/cmd/govulncheck@v1.1.3
+      curl -fsSL -o cli.tar.gz https://example.com/cli-1.2.3.tar.gz
+`)
+	if probe := runAssertion(HasNoUnpinnedCurlInstalls(), clean); probe.failed {
+		t.Errorf("pinned installs flagged: %v", probe.messages)
+	}
+
+	dirty := mustParse(t, `
+tasks:
+  - key: tools
+    run: |
+      curl -fsSL https://example.com/install.sh | sudo bash
+      go install github.com/some/tool@latest
+`)
+	probe := runAssertion(HasNoUnpinnedCurlInstalls(), dirty)
+	if !probe.failed {
+		t.Fatal("expected failure")
+	}
+	if len(probe.messages) != 2 {
+		t.Errorf("expected both idioms flagged: %v", probe.messages)
+	}
+}
+
+func TestInFile(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	for i := range cfg.Tasks {
+		cfg.Tasks[i].SourceFile = ".rwx/ci.yml"
+	}
+	cfg.Tasks = append(cfg.Tasks, RWXTask{Key: "deploy", Run: "./deploy.sh", SourceFile: ".rwx/deploy.yml"})
+
+	if probe := runAssertion(InFile(".rwx/deploy.yml", MinTaskCount(1)), cfg); probe.failed {
+		t.Errorf("InFile failed: %v", probe.messages)
+	}
+	// The ci tasks must not leak into the deploy scope.
+	if probe := runAssertion(InFile(".rwx/deploy.yml", MinTaskCount(2)), cfg); !probe.failed {
+		t.Error("expected failure: deploy file has one task")
+	}
+	if probe := runAssertion(InFile(".rwx/release.yml", MinTaskCount(1)), cfg); !probe.failed {
+		t.Error("expected failure for unknown file")
+	}
+}
+
+func TestHasProblemMatcherFreeOutput(t *testing.T) {
+	clean := mustParse(t, sampleConfig)
+	if probe := runAssertion(HasProblemMatcherFreeOutput(), clean); probe.failed {
+		t.Errorf("clean config flagged: %v", probe.messages)
+	}
+
+	dirty := mustParse(t, `
+tasks:
+  - key: build
+    run: |
+      go build ./... || echo "::error::build failed"
+      echo "version=1.0" >> $GITHUB_OUTPUT
+`)
+	probe := runAssertion(HasProblemMatcherFreeOutput(), dirty)
+	if !probe.failed {
+		t.Fatal("expected failure for GHA workflow commands")
+	}
+	if len(probe.messages) != 2 {
+		t.Errorf("expected both idioms flagged, got %v", probe.messages)
+	}
+}
+
+func TestAssertionBuilders(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+
+	hasBase := ConfigAssertionFunc("has_base", func(cfg *RWXConfig) error {
+		if cfg.Base == nil {
+			return fmt.Errorf("no base block")
+		}
+		return nil
+	})
+	if probe := runAssertion(hasBase, cfg); probe.failed {
+		t.Errorf("ConfigAssertionFunc failed: %v", probe.messages)
+	}
+	cfg.Base = nil
+	if probe := runAssertion(hasBase, cfg); !probe.failed {
+		t.Error("expected failure without base")
+	}
+
+	pinnedClones := TaskAssertion("clones_pin_sha", func(task RWXTask) error {
+		if strings.HasPrefix(task.Call, "git/clone") && task.With["ref"] == "main" {
+			return fmt.Errorf("ref should pin a commit sha, not a branch")
+		}
+		return nil
+	})
+	probe := runAssertion(pinnedClones, cfg)
+	if !probe.failed || len(probe.messages) != 1 {
+		t.Errorf("messages = %v", probe.messages)
+	}
+}
+
+func TestEnvVarValueAssertions(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: test
+    run: go test ./...
+    env:
+      DATABASE_URL: postgres://localhost:5432/app_test
+    background-processes:
+      - key: postgres
+        run: postgres
+        env:
+          POSTGRES_PASSWORD: postgres
+`)
+	if probe := runAssertion(EnvVarEquals("POSTGRES_PASSWORD", "postgres"), cfg); probe.failed {
+		t.Errorf("background-process env flagged: %v", probe.messages)
+	}
+	if probe := runAssertion(EnvVarMatches("DATABASE_URL", `^postgres://localhost:5432/`), cfg); probe.failed {
+		t.Errorf("matching env flagged: %v", probe.messages)
+	}
+	if probe := runAssertion(EnvVarEquals("DATABASE_URL", "mysql://localhost/app"), cfg); !probe.failed {
+		t.Error("expected failure for wrong value")
+	}
+	if probe := runAssertion(EnvVarMatches("REDIS_URL", `localhost`), cfg); !probe.failed {
+		t.Error("expected failure for unset var")
+	}
+}
+
+func TestHasWithValue(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(HasWithValue("golang/install", "go-version", "1.22"), cfg); probe.failed {
+		t.Errorf("exact with value flagged: %v", probe.messages)
+	}
+	if probe := runAssertion(HasWithValue("golang/install", "go-version", "1.26"), cfg); !probe.failed {
+		t.Error("expected failure for wrong value")
+	}
+	if probe := runAssertion(HasWithValue("golang/install", "cache", "true"), cfg); !probe.failed {
+		t.Error("expected failure for missing param")
+	}
+	if probe := runAssertion(HasWithValue("ruby/install", "ruby-version", "3.3"), cfg); !probe.failed {
+		t.Error("expected failure for uncalled package")
+	}
+}
+
+func TestHasWithMatching(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: code
+    call: git/clone 2.0.3
+    with:
+      repository: https://github.com/example/repo.git
+      ref: ${{ init.commit-sha }}
+`)
+	if probe := runAssertion(HasWithMatching("git/clone", "ref", `init\.commit-sha`), cfg); probe.failed {
+		t.Errorf("matching with value flagged: %v", probe.messages)
+	}
+	if probe := runAssertion(HasWithMatching("git/clone", "ref", `^main$`), cfg); !probe.failed {
+		t.Error("expected failure for non-matching value")
+	}
+}
+
+func TestForTask(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	ok := ForTask("test",
+		RunContains("go test"),
+		SetsEnv("CGO_ENABLED"),
+		FiltersOn("**/*.go"),
+		DependsOn("go"),
+	)
+	if probe := runAssertion(ok, cfg); probe.failed {
+		t.Errorf("ForTask failed: %v", probe.messages)
+	}
+
+	bad := ForTask("test",
+		RunContains("npm test"),
+		SetsEnv("DATABASE_URL"),
+		DependsOn("go"),
+	)
+	probe := runAssertion(bad, cfg)
+	if !probe.failed {
+		t.Fatal("expected failure")
+	}
+	if len(probe.messages) != 2 {
+		t.Errorf("expected one message per failing predicate: %v", probe.messages)
+	}
+
+	if probe := runAssertion(ForTask("nope", RunContains("x")), cfg); !probe.failed {
+		t.Error("expected failure for missing task")
+	}
+}
+
+func TestAll(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	ok := All("go_toolchain", UsesPackage("golang/install"), MinTaskCount(3))
+	if probe := runAssertion(ok, cfg); probe.failed {
+		t.Errorf("All failed: %v", probe.messages)
+	}
+
+	bad := All("impossible", UsesPackage("ruby/install"), MinTaskCount(10), MinTaskCount(2))
+	probe := runAssertion(bad, cfg)
+	if !probe.failed {
+		t.Fatal("All should fail when any child fails")
+	}
+	if len(probe.messages) != 2 {
+		t.Errorf("expected one message per failing child: %v", probe.messages)
+	}
+}
+
+func TestNot(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(Not(UsesPackage("nodejs/install")), cfg); probe.failed {
+		t.Errorf("Not failed on absent package: %v", probe.messages)
+	}
+	probe := runAssertion(Not(UsesPackage("golang/install")), cfg)
+	if !probe.failed {
+		t.Fatal("Not should fail when the wrapped assertion passes")
+	}
+	if !strings.Contains(probe.messages[0], "uses_package_golang_install") {
+		t.Errorf("failure should name the wrapped assertion: %v", probe.messages)
+	}
+	if got := Not(UsesPackage("nodejs/install")).Name; got != "not_uses_package_nodejs_install" {
+		t.Errorf("Name = %q", got)
+	}
+}
+
+func TestHasNotification(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: deploy
+    run: ./deploy.sh
+  - key: notify-failure
+    use: [deploy]
+    run: |
+      curl -X POST --data '{"text":"deploy failed"}' "$SLACK_WEBHOOK_URL"
+`)
+	if probe := runAssertion(HasNotification("webhook"), cfg); probe.failed {
+		t.Errorf("webhook notification flagged: %v", probe.messages)
+	}
+	if probe := runAssertion(HasNotification("email"), cfg); !probe.failed {
+		t.Error("expected failure for missing email notification")
+	}
+	if probe := runAssertion(HasNotification("pager"), cfg); !probe.failed {
+		t.Error("expected failure for unknown kind")
+	}
+
+	slack := mustParse(t, `
+tasks:
+  - key: notify
+    call: slack/notify 1.0.1
+    with:
+      channel: "#deploys"
+`)
+	if probe := runAssertion(HasNotification("slack"), slack); probe.failed {
+		t.Errorf("slack package call flagged: %v", probe.messages)
+	}
+}
+
+func TestHasConcurrencyControl(t *testing.T) {
+	cfg := mustParse(t, `
+concurrency-pools:
+  - id: example/repo:deploy-production
+    capacity: 1
+    on-overflow: cancel-waiting
+
+tasks:
+  - key: deploy
+    run: ./deploy.sh
+`)
+	if got := cfg.ConcurrencyPools[0].Capacity; got != 1 {
+		t.Errorf("capacity = %d", got)
+	}
+	if probe := runAssertion(HasConcurrencyControl(), cfg); probe.failed {
+		t.Errorf("pool present but flagged: %v", probe.messages)
+	}
+	if probe := runAssertion(HasConcurrencyControl(), mustParse(t, sampleConfig)); !probe.failed {
+		t.Error("expected failure without pools")
+	}
+}
+
+func TestNoOrphanTasks(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(NoOrphanTasks(), cfg); probe.failed {
+		t.Errorf("clean config flagged: %v", probe.messages)
+	}
+
+	// A leftover install task nothing uses, and an empty shell of a task.
+	cfg.Tasks = append(cfg.Tasks,
+		RWXTask{Key: "node", Call: "nodejs/install 1.1.2"},
+		RWXTask{Key: "stub"},
+	)
+	probe := runAssertion(NoOrphanTasks(), cfg)
+	if !probe.failed || len(probe.messages) != 2 {
+		t.Errorf("messages = %v", probe.messages)
+	}
+
+	// Terminal run tasks are legitimate leaves.
+	leaf := mustParse(t, "tasks:\n  - key: test\n    run: go test ./...\n")
+	if probe := runAssertion(NoOrphanTasks(), leaf); probe.failed {
+		t.Errorf("terminal leaf flagged: %v", probe.messages)
+	}
+}
+
+func TestAllCallsVersionPinned(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(AllCallsVersionPinned(), cfg); probe.failed {
+		t.Errorf("pinned calls flagged: %v", probe.messages)
+	}
+
+	cfg.Tasks = append(cfg.Tasks, RWXTask{Key: "node", Call: "nodejs/install"})
+	probe := runAssertion(AllCallsVersionPinned(), cfg)
+	if !probe.failed || len(probe.messages) != 1 {
+		t.Errorf("messages = %v", probe.messages)
+	}
+}
+
+func TestPackageVersionAtLeast(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(PackageVersionAtLeast("golang/install", "1.2.0"), cfg); probe.failed {
+		t.Errorf("current version flagged: %v", probe.messages)
+	}
+	if probe := runAssertion(PackageVersionAtLeast("golang/install", "1.10.0"), cfg); !probe.failed {
+		t.Error("expected failure below minimum")
+	}
+	if probe := runAssertion(PackageVersionAtLeast("ruby/install", "1.0.0"), cfg); !probe.failed {
+		t.Error("expected failure for uncalled package")
+	}
+}
+
+func TestCompareVersions(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"1.2.0", "1.2.0", 0},
+		{"1.2", "1.2.0", 0},
+		{"1.2.0", "1.10.0", -1},
+		{"2.0.3", "1.9.9", 1},
+		{"1.2.1", "1.2", 1},
+	}
+	for _, c := range cases {
+		if got := compareVersions(c.a, c.b); got != c.want {
+			t.Errorf("compareVersions(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestMatchesRWXSchema(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(MatchesRWXSchema(), cfg); probe.failed {
+		t.Errorf("clean config flagged: %v", probe.messages)
+	}
+}
+
+func TestHasParallelValues(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(HasParallelValues("go-version", "1.22", "1.26"), cfg); probe.failed {
+		t.Errorf("HasParallelValues failed: %v", probe.messages)
+	}
+	if probe := runAssertion(HasParallelValues("go-version", "1.21"), cfg); !probe.failed {
+		t.Error("expected failure for undeclared value")
+	}
+	if probe := runAssertion(HasParallelValues("node-version", "22"), cfg); !probe.failed {
+		t.Error("expected failure for undeclared key")
+	}
+}
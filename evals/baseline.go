@@ -0,0 +1,415 @@
+package evals
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+)
+
+var (
+	updateBaselines    = flag.Bool("update-baselines", false, "record current eval metrics as the new baselines")
+	acceptImprovements = flag.Bool("accept-improvements", false, "re-record baselines when current metrics are significantly better")
+)
+
+// Baseline captures the metrics of an accepted eval run, used to detect
+// regressions in later runs.
+type Baseline struct {
+	Eval        string `json:"eval"`
+	TotalTokens int    `json:"total_tokens"`
+	DurationMS  int64  `json:"duration_ms"`
+	NumTurns    int    `json:"num_turns,omitempty"`
+	// CostUSD is the run's total spend as reported by the agent. Tracked
+	// separately from tokens because model pricing and cache hit rates
+	// move the bill without moving the token count.
+	CostUSD float64 `json:"total_cost_usd,omitempty"`
+	// ThinkingTokens is estimated; see ExecutionResult.ThinkingTokens.
+	ThinkingTokens int      `json:"thinking_tokens,omitempty"`
+	ToolsUsed      []string `json:"tools_used,omitempty"`
+	SkillsUsed     []string `json:"skills_used,omitempty"`
+	// ToolCalls is the total number of tool invocations, with
+	// ToolCallCounts breaking it down per tool. A skill change that
+	// doubles Bash invocations is an efficiency regression even when the
+	// token count holds steady.
+	ToolCalls      int            `json:"tool_calls,omitempty"`
+	ToolCallCounts map[string]int `json:"tool_call_counts,omitempty"`
+	// FileWrites counts the distinct files touched by Write or Edit.
+	FileWrites int       `json:"file_writes,omitempty"`
+	RecordedAt time.Time `json:"recorded_at"`
+
+	// Model is the model the baseline was recorded with. Baselines are
+	// keyed per model so a sonnet run never clobbers or falsely regresses
+	// an opus one.
+	Model string `json:"model,omitempty"`
+
+	// RunID is the suite invocation that recorded this baseline, linking
+	// it back to the run's artifacts.
+	RunID string `json:"run_id,omitempty"`
+
+	// GitSHA is the commit the baseline was recorded at, so history
+	// entries can be walked back to the skill change behind a drift.
+	GitSHA string `json:"git_sha,omitempty"`
+
+	// PluginVersions records the plugin versions the baseline run loaded,
+	// so regressions can be correlated with skill releases and stale
+	// plugin caches show up as a version mismatch.
+	PluginVersions map[string]string `json:"plugin_versions,omitempty"`
+}
+
+// BaselineDir is where accepted baselines are stored, relative to the
+// evals package. EVALS_BASELINES_DIR overrides it, which CI uses when
+// restoring state from a previous run's artifact.
+func BaselineDir() string {
+	if dir := os.Getenv("EVALS_BASELINES_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(evalsPackageDir(), "testdata", "baselines")
+}
+
+func baselinePath(eval, model string) string {
+	name := sanitizeName(eval)
+	if model != "" {
+		name += "__" + sanitizeName(model)
+	}
+	return filepath.Join(BaselineDir(), name+".json")
+}
+
+// LoadBaseline reads the stored baseline for an eval under the currently
+// selected model (see RunOptions). It returns os.ErrNotExist if none has
+// been recorded.
+func LoadBaseline(eval string) (*Baseline, error) {
+	return LoadBaselineForModel(eval, RunOptions{}.model())
+}
+
+// LoadBaselineForModel reads the baseline recorded for an eval with a
+// specific model. Baselines recorded before the per-model scheme are
+// used as a fallback until re-recorded.
+func LoadBaselineForModel(eval, model string) (*Baseline, error) {
+	data, err := os.ReadFile(baselinePath(eval, model))
+	if model != "" && os.IsNotExist(err) {
+		data, err = os.ReadFile(baselinePath(eval, ""))
+	}
+	if err != nil {
+		return nil, err
+	}
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("parsing baseline for %s: %w", eval, err)
+	}
+	return &b, nil
+}
+
+// SaveBaseline writes the baseline for an eval and appends it to the
+// eval's history file (see AppendBaselineHistory).
+func SaveBaseline(b *Baseline) error {
+	if err := os.MkdirAll(BaselineDir(), 0o755); err != nil {
+		return err
+	}
+	data, err := marshalBaseline(b)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(baselinePath(b.Eval, b.Model), data, 0o644); err != nil {
+		return err
+	}
+	return AppendBaselineHistory(b)
+}
+
+func marshalBaseline(b *Baseline) ([]byte, error) {
+	data, err := json.MarshalIndent(b, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// BaselineFromResult builds a baseline record from a run's metrics.
+func BaselineFromResult(eval string, result *ExecutionResult) *Baseline {
+	return &Baseline{
+		Eval:           eval,
+		TotalTokens:    result.TotalTokens(),
+		DurationMS:     result.Duration.Milliseconds(),
+		NumTurns:       result.NumTurns(),
+		CostUSD:        result.CostUSD(),
+		ThinkingTokens: result.ThinkingTokens(),
+		ToolsUsed:      result.ToolsUsed(),
+		SkillsUsed:     result.SkillsUsed(),
+		ToolCalls:      result.ToolCallCount(),
+		ToolCallCounts: result.ToolCallCounts(),
+		FileWrites:     len(result.FilesWritten()),
+		RecordedAt:     time.Now().UTC(),
+		Model:          RunOptions{}.model(),
+		RunID:          RunID(),
+		GitSHA:         gitSHA(),
+		PluginVersions: result.PluginVersions(),
+	}
+}
+
+// medianOf returns the median of values. Used to aggregate metrics over
+// repeated runs, where it damps the single-run variance that makes raw
+// token counts trip the thresholds spuriously.
+func medianOf(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := make([]float64, len(values))
+	copy(sorted, values)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
+
+// aggregateResults builds a baseline record whose numeric metrics are
+// the medians across runs. Tools and skills are the union, since a tool
+// any run reached for is part of the eval's behavior.
+func aggregateResults(eval string, results []*ExecutionResult) *Baseline {
+	var tokens, durations, turns, thinking, costs, toolCalls, fileWrites []float64
+	toolSet := map[string]bool{}
+	skillSet := map[string]bool{}
+	perTool := map[string][]float64{}
+	var tools, skills []string
+	for _, result := range results {
+		tokens = append(tokens, float64(result.TotalTokens()))
+		durations = append(durations, float64(result.Duration.Milliseconds()))
+		turns = append(turns, float64(result.NumTurns()))
+		thinking = append(thinking, float64(result.ThinkingTokens()))
+		costs = append(costs, result.CostUSD())
+		toolCalls = append(toolCalls, float64(result.ToolCallCount()))
+		fileWrites = append(fileWrites, float64(len(result.FilesWritten())))
+		for tool, n := range result.ToolCallCounts() {
+			perTool[tool] = append(perTool[tool], float64(n))
+		}
+		for _, tool := range result.ToolsUsed() {
+			if !toolSet[tool] {
+				toolSet[tool] = true
+				tools = append(tools, tool)
+			}
+		}
+		for _, skill := range result.SkillsUsed() {
+			if !skillSet[skill] {
+				skillSet[skill] = true
+				skills = append(skills, skill)
+			}
+		}
+	}
+	toolCounts := map[string]int{}
+	for tool, samples := range perTool {
+		// Runs that never reached for the tool count as zero uses.
+		for len(samples) < len(results) {
+			samples = append(samples, 0)
+		}
+		if n := int(medianOf(samples)); n > 0 {
+			toolCounts[tool] = n
+		}
+	}
+	return &Baseline{
+		Eval:           eval,
+		TotalTokens:    int(medianOf(tokens)),
+		DurationMS:     int64(medianOf(durations)),
+		NumTurns:       int(medianOf(turns)),
+		CostUSD:        medianOf(costs),
+		ThinkingTokens: int(medianOf(thinking)),
+		ToolsUsed:      tools,
+		SkillsUsed:     skills,
+		ToolCalls:      int(medianOf(toolCalls)),
+		ToolCallCounts: toolCounts,
+		FileWrites:     int(medianOf(fileWrites)),
+		RecordedAt:     time.Now().UTC(),
+		Model:          RunOptions{}.model(),
+		RunID:          RunID(),
+		GitSHA:         gitSHA(),
+		PluginVersions: results[0].PluginVersions(),
+	}
+}
+
+// AssertNoRegression compares the run's metrics against the stored
+// baseline and fails the test when a metric regresses past its threshold.
+// With -update-baselines (or when no baseline exists) it records the
+// current metrics instead.
+func AssertNoRegression(t *testing.T, eval string, result *ExecutionResult) {
+	t.Helper()
+	assertNoRegression(t, eval, BaselineFromResult(eval, result))
+}
+
+// AssertNoRegressionN is AssertNoRegression over several runs of the
+// same eval: each metric is aggregated to its median before the
+// threshold comparison, so one outlier run doesn't read as a
+// regression. Baselines recorded through it hold the medians too.
+func AssertNoRegressionN(t *testing.T, eval string, results []*ExecutionResult) {
+	t.Helper()
+	if len(results) == 0 {
+		t.Fatalf("AssertNoRegressionN: no runs for %s", eval)
+	}
+	assertNoRegression(t, eval, aggregateResults(eval, results))
+}
+
+func assertNoRegression(t *testing.T, eval string, current *Baseline) {
+	t.Helper()
+	baseline, err := LoadBaselineForModel(eval, current.Model)
+	if *updateBaselines || os.IsNotExist(err) {
+		if err := SaveBaseline(current); err != nil {
+			t.Fatalf("saving baseline for %s: %v", eval, err)
+		}
+		t.Logf("recorded baseline for %s (%s): %d tokens, %dms", eval, current.Model, current.TotalTokens, current.DurationMS)
+		return
+	}
+	if err != nil {
+		t.Fatalf("loading baseline for %s: %v", eval, err)
+	}
+
+	for name, version := range current.PluginVersions {
+		if was := baseline.PluginVersions[name]; was != "" && was != version {
+			t.Logf("plugin %s version changed since baseline: %s -> %s", name, was, version)
+		}
+	}
+	compareUsage(t, current, baseline)
+	checkThreshold(t, "total_tokens", float64(current.TotalTokens), float64(baseline.TotalTokens), 20, 2000)
+	checkThreshold(t, "duration_ms", float64(current.DurationMS), float64(baseline.DurationMS), 50, 10000)
+	checkThreshold(t, "num_turns", float64(current.NumTurns), float64(baseline.NumTurns), 25, 2)
+	checkThreshold(t, "total_cost_usd", current.CostUSD, baseline.CostUSD, 25, 0.10)
+	checkThreshold(t, "tool_calls", float64(current.ToolCalls), float64(baseline.ToolCalls), 30, 5)
+	checkThreshold(t, "file_writes", float64(current.FileWrites), float64(baseline.FileWrites), 50, 3)
+	for _, tool := range sortedKeys(current.ToolCallCounts) {
+		if was := baseline.ToolCallCounts[tool]; was > 0 {
+			checkThreshold(t, "tool_calls["+tool+"]", float64(current.ToolCallCounts[tool]), float64(was), 100, 5)
+		}
+	}
+	checkThreshold(t, "thinking_tokens", float64(current.ThinkingTokens), float64(baseline.ThinkingTokens), 30, 500)
+
+	if improvements := detectImprovements(eval, current, baseline); len(improvements) > 0 {
+		for _, imp := range improvements {
+			data, _ := json.Marshal(imp)
+			t.Logf("improvement detected: %s", data)
+		}
+		if *acceptImprovements {
+			if err := SaveBaseline(current); err != nil {
+				t.Fatalf("re-recording baseline for %s: %v", eval, err)
+			}
+			t.Logf("re-recorded baseline for %s to capture the improvement", eval)
+		} else {
+			t.Logf("run with -accept-improvements to re-record the baseline for %s", eval)
+		}
+	}
+
+	history, err := LoadBaselineHistory(eval, current.Model)
+	if err != nil {
+		t.Logf("skipping trend check, could not read history: %v", err)
+		return
+	}
+	checkTrends(t, history, current)
+}
+
+// Improvement is a structured record of a metric that got significantly
+// better than its baseline, logged so improvements are as visible as
+// regressions instead of silently raising the bar only on -update runs.
+type Improvement struct {
+	Eval     string  `json:"eval"`
+	Metric   string  `json:"metric"`
+	Baseline float64 `json:"baseline"`
+	Current  float64 `json:"current"`
+	DeltaPct float64 `json:"delta_pct"`
+}
+
+// detectImprovements returns the metrics that improved past the same
+// percentage and absolute-delta bars the regression checks use, just
+// pointed in the other direction.
+func detectImprovements(eval string, current, baseline *Baseline) []Improvement {
+	metrics := []struct {
+		name              string
+		current, baseline float64
+		pct, minDelta     float64
+	}{
+		{"total_tokens", float64(current.TotalTokens), float64(baseline.TotalTokens), 20, 2000},
+		{"duration_ms", float64(current.DurationMS), float64(baseline.DurationMS), 50, 10000},
+		{"num_turns", float64(current.NumTurns), float64(baseline.NumTurns), 25, 2},
+		{"total_cost_usd", current.CostUSD, baseline.CostUSD, 25, 0.10},
+	}
+	var improvements []Improvement
+	for _, m := range metrics {
+		if m.baseline <= 0 {
+			continue
+		}
+		decrease := (m.baseline - m.current) / m.baseline * 100
+		if decrease > m.pct && m.baseline-m.current >= m.minDelta {
+			improvements = append(improvements, Improvement{
+				Eval:     eval,
+				Metric:   m.name,
+				Baseline: m.baseline,
+				Current:  m.current,
+				DeltaPct: -decrease,
+			})
+		}
+	}
+	return improvements
+}
+
+// heavyweightTools are tools whose appearance in a run that previously
+// managed without them usually signals the skill has started casting a
+// wider (and slower, costlier) net.
+var heavyweightTools = map[string]bool{
+	"WebSearch": true,
+	"Task":      true,
+}
+
+// compareUsage checks the run's tool and skill usage against the
+// baseline. A skill the baseline run used going missing is a hard
+// failure — the eval is no longer exercising what it exists to exercise.
+// Heavyweight tools showing up for the first time are only logged, since
+// they are sometimes a legitimate response to a fixture change.
+func compareUsage(t TB, current, baseline *Baseline) {
+	t.Helper()
+	used := map[string]bool{}
+	for _, skill := range current.SkillsUsed {
+		used[skill] = true
+	}
+	for _, skill := range baseline.SkillsUsed {
+		if !used[skill] {
+			t.Errorf("skill %q was used in the baseline run but not this one; run with -update-baselines to accept", skill)
+		}
+	}
+	was := map[string]bool{}
+	for _, tool := range baseline.ToolsUsed {
+		was[tool] = true
+	}
+	for _, tool := range current.ToolsUsed {
+		if heavyweightTools[tool] && !was[tool] {
+			t.Logf("warning: heavyweight tool %s was not used in the baseline run", tool)
+		}
+	}
+}
+
+// checkThreshold fails the test when current exceeds baseline by more
+// than maxIncreasePct percent AND by at least minDelta in absolute
+// terms. The absolute floor keeps tiny baselines from turning noise into
+// huge percentage jumps — a 50-token eval growing by 30 tokens is not a
+// regression worth a red build.
+func checkThreshold(t *testing.T, metric string, current, baseline, maxIncreasePct, minDelta float64) {
+	t.Helper()
+	if baseline <= 0 {
+		return
+	}
+	increase := (current - baseline) / baseline * 100
+	if increase > maxIncreasePct && current-baseline >= minDelta {
+		t.Errorf("%s regressed: %s -> %s (+%.1f%%, threshold %.0f%%); run with -update-baselines to accept",
+			metric, formatMetric(baseline), formatMetric(current), increase, maxIncreasePct)
+	} else {
+		t.Logf("%s: %s (baseline %s, %+.1f%%)", metric, formatMetric(current), formatMetric(baseline), increase)
+	}
+}
+
+// formatMetric renders a metric value without fractional noise on the
+// integer-valued metrics while keeping sub-dollar costs readable.
+func formatMetric(v float64) string {
+	if v == math.Trunc(v) {
+		return fmt.Sprintf("%.0f", v)
+	}
+	return fmt.Sprintf("%.4f", v)
+}
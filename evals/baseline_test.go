@@ -0,0 +1,175 @@
+package evals
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPerModelBaselines(t *testing.T) {
+	t.Setenv("EVALS_BASELINES_DIR", t.TempDir())
+
+	sonnet := &Baseline{Eval: "e", Model: "sonnet", TotalTokens: 100}
+	opus := &Baseline{Eval: "e", Model: "opus", TotalTokens: 300}
+	if err := SaveBaseline(sonnet); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveBaseline(opus); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadBaselineForModel("e", "sonnet")
+	if err != nil || got.TotalTokens != 100 {
+		t.Errorf("sonnet baseline = %+v, err %v", got, err)
+	}
+	got, err = LoadBaselineForModel("e", "opus")
+	if err != nil || got.TotalTokens != 300 {
+		t.Errorf("opus baseline = %+v, err %v", got, err)
+	}
+
+	t.Setenv("EVALS_MODEL", "opus")
+	got, err = LoadBaseline("e")
+	if err != nil || got.Model != "opus" {
+		t.Errorf("LoadBaseline under EVALS_MODEL=opus = %+v, err %v", got, err)
+	}
+}
+
+func TestMedianOf(t *testing.T) {
+	if got := medianOf([]float64{3, 1, 2}); got != 2 {
+		t.Errorf("odd median = %v", got)
+	}
+	if got := medianOf([]float64{1, 2, 3, 100}); got != 2.5 {
+		t.Errorf("even median = %v", got)
+	}
+	if got := medianOf(nil); got != 0 {
+		t.Errorf("empty median = %v", got)
+	}
+}
+
+func TestAggregateResults(t *testing.T) {
+	run := func(tokens, turns int, cost float64, tool string) *ExecutionResult {
+		return &ExecutionResult{Events: []ClaudeEvent{
+			{Type: "assistant", Message: &ClaudeMessage{
+				Content: []ContentBlock{{Type: "tool_use", Name: tool}},
+				Usage:   &ClaudeUsage{InputTokens: tokens},
+			}},
+			{Type: "result", Subtype: "success", NumTurns: turns, TotalCostUSD: cost},
+		}}
+	}
+	agg := aggregateResults("agg_eval", []*ExecutionResult{
+		run(1000, 10, 0.25, "Read"),
+		run(5000, 11, 1.50, "Bash"), // outlier run
+		run(1100, 10, 0.50, "Read"),
+	})
+	if agg.TotalTokens != 1100 {
+		t.Errorf("median tokens = %d", agg.TotalTokens)
+	}
+	if agg.NumTurns != 10 {
+		t.Errorf("median turns = %d", agg.NumTurns)
+	}
+	if agg.CostUSD != 0.50 {
+		t.Errorf("median cost = %v", agg.CostUSD)
+	}
+	if len(agg.ToolsUsed) != 2 {
+		t.Errorf("tool union = %v", agg.ToolsUsed)
+	}
+	if agg.ToolCalls != 1 {
+		t.Errorf("median tool calls = %d", agg.ToolCalls)
+	}
+	// Read shows up in two of three runs; Bash only in the outlier, so
+	// its per-tool median is zero and it drops out.
+	if agg.ToolCallCounts["Read"] != 1 || agg.ToolCallCounts["Bash"] != 0 {
+		t.Errorf("tool call counts = %v", agg.ToolCallCounts)
+	}
+}
+
+func TestCompareUsage(t *testing.T) {
+	baseline := &Baseline{
+		SkillsUsed: []string{"migrate-from-gha"},
+		ToolsUsed:  []string{"Read", "Write", "Bash"},
+	}
+
+	probe := &probeTB{}
+	compareUsage(probe, &Baseline{
+		SkillsUsed: []string{"migrate-from-gha"},
+		ToolsUsed:  []string{"Read", "Bash"},
+	}, baseline)
+	if probe.failed {
+		t.Errorf("matching usage failed: %v", probe.messages)
+	}
+
+	probe = &probeTB{}
+	compareUsage(probe, &Baseline{ToolsUsed: []string{"Read"}}, baseline)
+	if !probe.failed {
+		t.Error("dropped skill should fail")
+	}
+
+	probe = &probeTB{}
+	compareUsage(probe, &Baseline{
+		SkillsUsed: []string{"migrate-from-gha"},
+		ToolsUsed:  []string{"Read", "WebSearch"},
+	}, baseline)
+	if probe.failed {
+		t.Errorf("new heavyweight tool should only warn: %v", probe.messages)
+	}
+	if len(probe.messages) != 1 || !strings.Contains(probe.messages[0], "WebSearch") {
+		t.Errorf("messages = %v", probe.messages)
+	}
+}
+
+func TestDetectImprovements(t *testing.T) {
+	baseline := &Baseline{TotalTokens: 10000, DurationMS: 60000, NumTurns: 12, CostUSD: 1.00}
+
+	got := detectImprovements("e", &Baseline{TotalTokens: 5000, DurationMS: 58000, NumTurns: 11, CostUSD: 0.50}, baseline)
+	if len(got) != 2 {
+		t.Fatalf("improvements = %+v", got)
+	}
+	if got[0].Metric != "total_tokens" || got[0].DeltaPct != -50 {
+		t.Errorf("tokens improvement = %+v", got[0])
+	}
+	if got[1].Metric != "total_cost_usd" {
+		t.Errorf("cost improvement = %+v", got[1])
+	}
+
+	// Large in percent but under the absolute floor: not an improvement.
+	if got := detectImprovements("e", &Baseline{TotalTokens: 9000, NumTurns: 11, CostUSD: 0.95}, &Baseline{TotalTokens: 10000, NumTurns: 12, CostUSD: 1.00}); got != nil {
+		t.Errorf("small deltas flagged: %+v", got)
+	}
+	if got := detectImprovements("e", &Baseline{}, &Baseline{}); got != nil {
+		t.Errorf("zero baselines flagged: %+v", got)
+	}
+}
+
+func TestAcceptImprovements(t *testing.T) {
+	t.Setenv("EVALS_BASELINES_DIR", t.TempDir())
+	if err := SaveBaseline(&Baseline{Eval: "e", TotalTokens: 10000, DurationMS: 60000}); err != nil {
+		t.Fatal(err)
+	}
+
+	*acceptImprovements = true
+	defer func() { *acceptImprovements = false }()
+	assertNoRegression(t, "e", &Baseline{Eval: "e", TotalTokens: 4000, DurationMS: 20000})
+
+	got, err := LoadBaselineForModel("e", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.TotalTokens != 4000 {
+		t.Errorf("baseline after accepting improvement = %+v", got)
+	}
+}
+
+func TestLoadBaselineLegacyFallback(t *testing.T) {
+	t.Setenv("EVALS_BASELINES_DIR", t.TempDir())
+
+	legacy := &Baseline{Eval: "e", TotalTokens: 150}
+	if err := SaveBaseline(legacy); err != nil {
+		t.Fatal(err)
+	}
+	got, err := LoadBaselineForModel("e", "sonnet")
+	if err != nil {
+		t.Fatalf("expected legacy fallback, got %v", err)
+	}
+	if got.TotalTokens != 150 {
+		t.Errorf("TotalTokens = %d", got.TotalTokens)
+	}
+}
@@ -0,0 +1,90 @@
+package evals
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// CacheDir is where cached runs live. EVALS_CACHE_DIR overrides it,
+// which CI uses to persist the cache between jobs.
+func CacheDir() string {
+	if dir := os.Getenv("EVALS_CACHE_DIR"); dir != "" {
+		return dir
+	}
+	return filepath.Join(evalsPackageDir(), "testdata", "cache")
+}
+
+// RunCacheKey hashes everything that could change a run's outcome: the
+// prompt, the fixture tree in workDir, and the skill files under
+// skillDir. Two runs with the same key would put the agent in the same
+// starting position, so the earlier result can stand in for the later
+// one.
+func RunCacheKey(prompt, workDir, skillDir string) (string, error) {
+	h := sha256.New()
+	io.WriteString(h, prompt)
+	io.WriteString(h, "\x00")
+	for _, dir := range []string{workDir, skillDir} {
+		snapshot, err := SnapshotWorkspace(dir)
+		if err != nil {
+			return "", fmt.Errorf("hashing %s: %w", dir, err)
+		}
+		for _, path := range sortedKeys(snapshot) {
+			io.WriteString(h, path)
+			io.WriteString(h, "\x00")
+			io.WriteString(h, snapshot[path].Hash)
+			io.WriteString(h, "\x00")
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))[:16], nil
+}
+
+// CachingRunner wraps an AgentRunner with content-addressed reuse: when
+// the prompt, fixture tree, and skill files all match a recorded run,
+// the recorded result is replayed instead of calling the agent. That
+// makes iterating on assertions essentially free — only changes to what
+// the agent would actually see trigger a fresh run.
+type CachingRunner struct {
+	Runner AgentRunner
+	// Dir overrides CacheDir, for tests.
+	Dir string
+	// SkillDir overrides PluginDir as the skill tree to hash, for tests.
+	SkillDir string
+}
+
+// Run implements AgentRunner. The cache key is computed before the run,
+// while workDir still holds the untouched fixture.
+func (r *CachingRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	dir := r.Dir
+	if dir == "" {
+		dir = CacheDir()
+	}
+	skillDir := r.SkillDir
+	if skillDir == "" {
+		skillDir = PluginDir()
+	}
+	key, err := RunCacheKey(prompt, workDir, skillDir)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, err := LoadCassette(dir, key); err == nil {
+		replay := &ReplayRunner{Name: key, Dir: dir}
+		return replay.Run(ctx, prompt, workDir)
+	}
+
+	result, err := r.Runner.Run(ctx, prompt, workDir)
+	if err != nil {
+		return result, err
+	}
+	if err := RecordCassette(dir, key, prompt, result, workDir); err != nil {
+		return result, fmt.Errorf("caching run %s: %w", key, err)
+	}
+	return result, nil
+}
+
+var _ AgentRunner = (*CachingRunner)(nil)
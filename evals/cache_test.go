@@ -0,0 +1,91 @@
+package evals
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCachingRunner(t *testing.T) {
+	workDir := t.TempDir()
+	skillDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "go.mod"), []byte("module example.com/app\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("migrate things\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	fake := &FakeRunner{Files: map[string]string{".rwx/ci.yml": "tasks: []\n"}}
+	runner := &CachingRunner{Runner: fake, Dir: t.TempDir(), SkillDir: skillDir}
+
+	first, err := runner.Run(context.Background(), "migrate", workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Prompts) != 1 {
+		t.Fatalf("first run should call the agent, prompts = %v", fake.Prompts)
+	}
+
+	// Same prompt, fixture, and skill: served from cache.
+	replayDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(replayDir, "go.mod"), []byte("module example.com/app\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	second, err := runner.Run(context.Background(), "migrate", replayDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Prompts) != 1 {
+		t.Errorf("cache hit should not call the agent, prompts = %v", fake.Prompts)
+	}
+	if first.ResultEvent() == nil || second.ResultEvent() == nil {
+		t.Error("both runs should carry a result event")
+	}
+	if _, err := os.Stat(filepath.Join(replayDir, ".rwx", "ci.yml")); err != nil {
+		t.Errorf("cache hit should restore generated files: %v", err)
+	}
+
+	// A skill edit invalidates the key.
+	if err := os.WriteFile(filepath.Join(skillDir, "SKILL.md"), []byte("migrate things, carefully\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	freshDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(freshDir, "go.mod"), []byte("module example.com/app\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runner.Run(context.Background(), "migrate", freshDir); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Prompts) != 2 {
+		t.Errorf("changed skill should trigger a fresh run, prompts = %v", fake.Prompts)
+	}
+}
+
+func TestRunCacheKeyStable(t *testing.T) {
+	workDir := t.TempDir()
+	skillDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(workDir, "main.go"), []byte("package main\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	key1, err := RunCacheKey("prompt", workDir, skillDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key2, err := RunCacheKey("prompt", workDir, skillDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key1 != key2 {
+		t.Errorf("key not stable: %s vs %s", key1, key2)
+	}
+	key3, err := RunCacheKey("other prompt", workDir, skillDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key3 == key1 {
+		t.Error("prompt change should change the key")
+	}
+}
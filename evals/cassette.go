@@ -0,0 +1,153 @@
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Cassette is a recorded agent run: the prompt, the raw transcript, and
+// the files the agent left under .rwx. Replaying one re-executes
+// assertions against the recorded output without calling the agent, so
+// assertion changes iterate quickly and the harness plumbing gets unit
+// coverage.
+type Cassette struct {
+	Name       string            `json:"name"`
+	Prompt     string            `json:"prompt"`
+	RecordedAt time.Time         `json:"recorded_at"`
+	Transcript json.RawMessage   `json:"transcript"`
+	Files      map[string]string `json:"files"`
+}
+
+// CassetteDir is where cassettes live by default.
+func CassetteDir() string {
+	return filepath.Join(evalsPackageDir(), "testdata", "cassettes")
+}
+
+// RecordCassette writes a cassette capturing the transcript and the .rwx
+// tree the run produced in workDir.
+func RecordCassette(dir, name, prompt string, result *ExecutionResult, workDir string) error {
+	if dir == "" {
+		dir = CassetteDir()
+	}
+	files := map[string]string{}
+	rwxDir := filepath.Join(workDir, ".rwx")
+	err := filepath.WalkDir(rwxDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(workDir, path)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = string(data)
+		return nil
+	})
+	if err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("snapshotting workspace: %w", err)
+	}
+
+	cassette := Cassette{
+		Name:       name,
+		Prompt:     prompt,
+		RecordedAt: time.Now().UTC(),
+		Transcript: json.RawMessage(result.Raw),
+		Files:      files,
+	}
+	data, err := json.MarshalIndent(cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, name+".json"), append(data, '\n'), 0o644)
+}
+
+// LoadCassette reads a recorded cassette by name.
+func LoadCassette(dir, name string) (*Cassette, error) {
+	if dir == "" {
+		dir = CassetteDir()
+	}
+	data, err := os.ReadFile(filepath.Join(dir, name+".json"))
+	if err != nil {
+		return nil, err
+	}
+	var cassette Cassette
+	if err := json.Unmarshal(data, &cassette); err != nil {
+		return nil, fmt.Errorf("parsing cassette %s: %w", name, err)
+	}
+	return &cassette, nil
+}
+
+// RecordingRunner wraps an AgentRunner and writes a cassette after each
+// successful run. Set EVALS_RECORD=1 in the harness to wire it in.
+type RecordingRunner struct {
+	Runner AgentRunner
+	// Name is the cassette name; required.
+	Name string
+	// Dir overrides CassetteDir, for tests.
+	Dir string
+}
+
+// Run implements AgentRunner.
+func (r *RecordingRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	result, err := r.Runner.Run(ctx, prompt, workDir)
+	if err != nil {
+		return result, err
+	}
+	if err := RecordCassette(r.Dir, r.Name, prompt, result, workDir); err != nil {
+		return result, fmt.Errorf("recording cassette %s: %w", r.Name, err)
+	}
+	return result, nil
+}
+
+// ReplayRunner replays a recorded cassette: it restores the recorded
+// files into workDir and returns the recorded transcript without
+// invoking any agent.
+type ReplayRunner struct {
+	// Name is the cassette name; required.
+	Name string
+	// Dir overrides CassetteDir, for tests.
+	Dir string
+}
+
+// Run implements AgentRunner. The prompt is ignored; the cassette's
+// recorded prompt governs what happened.
+func (r *ReplayRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	cassette, err := LoadCassette(r.Dir, r.Name)
+	if err != nil {
+		return nil, err
+	}
+	for rel, content := range cassette.Files {
+		path := filepath.Join(workDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+	}
+	events, err := parseClaudeOutput(cassette.Transcript)
+	if err != nil {
+		return nil, fmt.Errorf("parsing cassette %s transcript: %w", r.Name, err)
+	}
+	result := &ExecutionResult{Events: events, Raw: cassette.Transcript}
+	if res := result.ResultEvent(); res != nil {
+		result.Duration = time.Duration(res.DurationMS) * time.Millisecond
+	}
+	return result, nil
+}
+
+var (
+	_ AgentRunner = (*RecordingRunner)(nil)
+	_ AgentRunner = (*ReplayRunner)(nil)
+)
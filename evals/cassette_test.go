@@ -0,0 +1,63 @@
+package evals
+
+import (
+	"context"
+	"testing"
+)
+
+func TestCassetteRecordReplay(t *testing.T) {
+	cassetteDir := t.TempDir()
+	events := []ClaudeEvent{
+		{Type: "assistant", Message: &ClaudeMessage{Role: "assistant", Content: []ContentBlock{{Type: "tool_use", Name: "Write", Input: []byte("{}")}}}},
+		{Type: "result", Subtype: "success", DurationMS: 1500},
+	}
+
+	recorder := &RecordingRunner{
+		Runner: &FakeRunner{Events: events, Files: map[string]string{".rwx/ci.yml": sampleConfig}},
+		Name:   "go_simple",
+		Dir:    cassetteDir,
+	}
+	recordDir := t.TempDir()
+	if _, err := recorder.Run(context.Background(), "migrate this repo", recordDir); err != nil {
+		t.Fatal(err)
+	}
+
+	cassette, err := LoadCassette(cassetteDir, "go_simple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cassette.Prompt != "migrate this repo" {
+		t.Errorf("Prompt = %q", cassette.Prompt)
+	}
+	if _, ok := cassette.Files[".rwx/ci.yml"]; !ok {
+		t.Fatalf("Files = %v", cassette.Files)
+	}
+
+	replayDir := t.TempDir()
+	replay := &ReplayRunner{Name: "go_simple", Dir: cassetteDir}
+	result, err := replay.Run(context.Background(), "", replayDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.ToolsUsed(); len(got) != 1 || got[0] != "Write" {
+		t.Errorf("ToolsUsed = %v", got)
+	}
+	if result.Duration.Milliseconds() != 1500 {
+		t.Errorf("Duration = %v", result.Duration)
+	}
+
+	results, err := EvaluateConfig(replayDir, ClonesRepo(), InstallsGo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results.Passed() {
+		t.Errorf("replayed workspace failed assertions: %+v", results.Failures())
+	}
+}
+
+func TestReplayRunnerMissingCassette(t *testing.T) {
+	replay := &ReplayRunner{Name: "nope", Dir: t.TempDir()}
+	if _, err := replay.Run(context.Background(), "", t.TempDir()); err == nil {
+		t.Error("expected error for missing cassette")
+	}
+}
@@ -0,0 +1,128 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// PackageCatalog is a snapshot of the RWX package registry: package
+// names and the versions published for each. Evals use it to fail fast
+// when an agent hallucinates a package or version, instead of leaving
+// that to rwx lint on a machine that has the CLI.
+type PackageCatalog struct {
+	versions map[string][]string
+}
+
+// catalogFile is the JSON shape of a vendored snapshot, which matches
+// `rwx packages list --output json`.
+type catalogFile struct {
+	Packages []struct {
+		Name     string   `json:"name"`
+		Versions []string `json:"versions"`
+	} `json:"packages"`
+}
+
+// LoadPackageCatalog reads a registry snapshot from disk.
+func LoadPackageCatalog(path string) (*PackageCatalog, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var file catalogFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("parsing package catalog %s: %w", path, err)
+	}
+	cat := &PackageCatalog{versions: make(map[string][]string, len(file.Packages))}
+	for _, pkg := range file.Packages {
+		cat.versions[pkg.Name] = pkg.Versions
+	}
+	return cat, nil
+}
+
+var (
+	defaultCatalogOnce sync.Once
+	defaultCatalog     *PackageCatalog
+	defaultCatalogErr  error
+)
+
+// DefaultPackageCatalog returns the snapshot vendored in
+// testdata/packages.json, loading it once per process.
+func DefaultPackageCatalog() (*PackageCatalog, error) {
+	defaultCatalogOnce.Do(func() {
+		path := filepath.Join(evalsPackageDir(), "testdata", "packages.json")
+		defaultCatalog, defaultCatalogErr = LoadPackageCatalog(path)
+	})
+	return defaultCatalog, defaultCatalogErr
+}
+
+// Has reports whether the catalog knows the package.
+func (c *PackageCatalog) Has(name string) bool {
+	_, ok := c.versions[name]
+	return ok
+}
+
+// HasVersion reports whether the catalog knows the exact version of the
+// package.
+func (c *PackageCatalog) HasVersion(name, version string) bool {
+	for _, v := range c.versions[name] {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
+
+// splitCall separates an RWX call into package name and version; the
+// version is empty when the call is unpinned.
+func splitCall(call string) (name, version string) {
+	name, version, _ = strings.Cut(call, " ")
+	return name, version
+}
+
+// PackageExists asserts every task call references a package the
+// catalog knows about.
+func PackageExists(cat *PackageCatalog) ConfigAssertion {
+	return ConfigAssertion{
+		Name: "packages_exist",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				if task.Call == "" {
+					continue
+				}
+				name, _ := splitCall(task.Call)
+				if !cat.Has(name) {
+					t.Errorf("task %q calls unknown package %q", task.Key, name)
+				}
+			}
+		},
+	}
+}
+
+// PackageVersionExists asserts every pinned task call references a
+// published version of its package. Unpinned calls and unknown packages
+// are left to PackageExists.
+func PackageVersionExists(cat *PackageCatalog) ConfigAssertion {
+	return ConfigAssertion{
+		Name: "package_versions_exist",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				if task.Call == "" {
+					continue
+				}
+				name, version := splitCall(task.Call)
+				if version == "" || !cat.Has(name) {
+					continue
+				}
+				if !cat.HasVersion(name, version) {
+					t.Errorf("task %q calls %s %s, which is not a published version", task.Key, name, version)
+				}
+			}
+		},
+	}
+}
@@ -0,0 +1,55 @@
+package evals
+
+import "testing"
+
+func TestDefaultPackageCatalog(t *testing.T) {
+	cat, err := DefaultPackageCatalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cat.Has("git/clone") {
+		t.Error("vendored snapshot missing git/clone")
+	}
+	if cat.Has("totally/hallucinated") {
+		t.Error("unknown package reported as present")
+	}
+	if !cat.HasVersion("golang/install", "1.2.0") {
+		t.Error("published version reported missing")
+	}
+	if cat.HasVersion("golang/install", "9.9.9") {
+		t.Error("unpublished version reported present")
+	}
+}
+
+func TestPackageExistenceAssertions(t *testing.T) {
+	cat, err := DefaultPackageCatalog()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg := mustParse(t, sampleConfig)
+	if probe := runAssertion(PackageExists(cat), cfg); probe.failed {
+		t.Errorf("known packages flagged: %v", probe.messages)
+	}
+	if probe := runAssertion(PackageVersionExists(cat), cfg); probe.failed {
+		t.Errorf("published versions flagged: %v", probe.messages)
+	}
+
+	hallucinated := mustParse(t, `
+tasks:
+  - key: code
+    call: github/checkout 4.0.0
+  - key: go
+    call: golang/install 9.9.9
+  - key: unpinned
+    call: nodejs/install
+`)
+	probe := runAssertion(PackageExists(cat), hallucinated)
+	if !probe.failed || len(probe.messages) != 1 {
+		t.Errorf("PackageExists messages = %v", probe.messages)
+	}
+	probe = runAssertion(PackageVersionExists(cat), hallucinated)
+	if !probe.failed || len(probe.messages) != 1 {
+		t.Errorf("PackageVersionExists messages = %v", probe.messages)
+	}
+}
@@ -0,0 +1,691 @@
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ClaudeEvent is one entry in the claude CLI's JSON output stream. Fields
+// are populated depending on the event type (system, assistant, user,
+// result).
+type ClaudeEvent struct {
+	Type    string         `json:"type"`
+	Subtype string         `json:"subtype"`
+	Message *ClaudeMessage `json:"message"`
+	// ParentToolUseID links events emitted inside a Task-tool subagent
+	// back to the spawning tool call; empty for main-thread events.
+	ParentToolUseID string       `json:"parent_tool_use_id,omitempty"`
+	Result          string       `json:"result"`
+	IsError         bool         `json:"is_error"`
+	DurationMS      int64        `json:"duration_ms"`
+	NumTurns        int          `json:"num_turns"`
+	TotalCostUSD    float64      `json:"total_cost_usd"`
+	Usage           *ClaudeUsage `json:"usage"`
+	// PermissionDenials lists the tool calls blocked by permission
+	// prompts, reported on the result event.
+	PermissionDenials []PermissionDenial `json:"permission_denials,omitempty"`
+
+	// system/init fields, describing what the harness loaded at startup.
+	Tools         []string     `json:"tools,omitempty"`
+	SlashCommands []string     `json:"slash_commands,omitempty"`
+	Plugins       []PluginInfo `json:"plugins,omitempty"`
+}
+
+// PluginInfo describes a plugin the harness loaded, from the init event.
+type PluginInfo struct {
+	Name    string `json:"name"`
+	Version string `json:"version,omitempty"`
+	Path    string `json:"path,omitempty"`
+}
+
+// PermissionDenial is one tool call the harness refused to run because
+// the user (or the permission config) denied it.
+type PermissionDenial struct {
+	ToolName  string          `json:"tool_name"`
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	ToolInput json.RawMessage `json:"tool_input,omitempty"`
+}
+
+// ClaudeMessage is the API message carried by assistant and user events.
+type ClaudeMessage struct {
+	Role    string         `json:"role"`
+	Content []ContentBlock `json:"content"`
+	Usage   *ClaudeUsage   `json:"usage"`
+}
+
+// ContentBlock is a single content block within a message.
+type ContentBlock struct {
+	Type     string          `json:"type"`
+	ID       string          `json:"id,omitempty"`
+	Text     string          `json:"text"`
+	Thinking string          `json:"thinking,omitempty"`
+	Name     string          `json:"name"`
+	Input    json.RawMessage `json:"input"`
+
+	// tool_result fields. Content is raw because the API sends either a
+	// plain string or a list of nested blocks.
+	ToolUseID string          `json:"tool_use_id,omitempty"`
+	IsError   bool            `json:"is_error,omitempty"`
+	Content   json.RawMessage `json:"content,omitempty"`
+}
+
+// ClaudeUsage is the token accounting attached to messages and results.
+type ClaudeUsage struct {
+	InputTokens              int `json:"input_tokens"`
+	OutputTokens             int `json:"output_tokens"`
+	CacheCreationInputTokens int `json:"cache_creation_input_tokens"`
+	CacheReadInputTokens     int `json:"cache_read_input_tokens"`
+}
+
+// ExecutionResult holds the full transcript of one agent run.
+type ExecutionResult struct {
+	Events   []ClaudeEvent
+	Raw      []byte
+	Duration time.Duration
+
+	// PreSnapshot and PostSnapshot are workspace snapshots taken around
+	// the run, when the runner supports them. See WorkspaceDiff.
+	PreSnapshot  WorkspaceSnapshot
+	PostSnapshot WorkspaceSnapshot
+}
+
+// RunOptions customizes an agent run.
+type RunOptions struct {
+	// Model is passed via --model. Empty falls back to the EVALS_MODEL
+	// environment variable, then to "sonnet".
+	Model string
+	// MaxTurns bounds the number of agent turns via --max-turns. Zero
+	// means the CLI default (unbounded).
+	MaxTurns int
+	// AllowedTools restricts the run to the named tools via
+	// --allowedTools. Empty leaves the CLI default.
+	AllowedTools []string
+	// DisallowedTools blocks the named tools via --disallowedTools.
+	DisallowedTools []string
+	// AppendSystemPrompt adds steering text to the system prompt via
+	// --append-system-prompt, so experiments can A/B different steering
+	// against the same skill and fixtures.
+	AppendSystemPrompt string
+	// Env adds per-run environment variables to the claude process (e.g.
+	// a fake RWX_ACCESS_TOKEN or git author settings), on top of the test
+	// process's environment. Setting them here instead of via os.Setenv
+	// keeps parallel tests from leaking env into each other.
+	Env map[string]string
+	// PluginDir overrides the plugin directory the run loads skills
+	// from. Empty uses PluginDir(), the checkout's own plugins/rwx; A/B
+	// runs point it at worktrees of the skills repo at other commits.
+	PluginDir string
+}
+
+func (o RunOptions) pluginDir() string {
+	if o.PluginDir != "" {
+		return o.PluginDir
+	}
+	return PluginDir()
+}
+
+// environ merges o.Env over the current process environment, for the
+// subprocess's cmd.Env. Returns nil (inherit everything) when Env is
+// empty.
+func (o RunOptions) environ() []string {
+	if len(o.Env) == 0 {
+		return nil
+	}
+	env := os.Environ()
+	for _, key := range sortedKeys(o.Env) {
+		env = append(env, key+"="+o.Env[key])
+	}
+	return env
+}
+
+func (o RunOptions) model() string {
+	if o.Model != "" {
+		return o.Model
+	}
+	if model := os.Getenv("EVALS_MODEL"); model != "" {
+		return model
+	}
+	return "sonnet"
+}
+
+// RunClaude runs the claude CLI against workDir with the RWX plugin loaded
+// and returns the parsed transcript. The caller is expected to bound the
+// run via ctx.
+func RunClaude(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	return RunClaudeWith(ctx, prompt, workDir, RunOptions{})
+}
+
+// claudeArgs builds the CLI argument list for a one-shot run.
+func claudeArgs(prompt string, opts RunOptions) []string {
+	args := []string{
+		"-p", prompt,
+		"--output-format", "json",
+		"--verbose",
+		"--dangerously-skip-permissions",
+		"--model", opts.model(),
+		"--plugin-dir", opts.pluginDir(),
+	}
+	if opts.MaxTurns > 0 {
+		args = append(args, "--max-turns", strconv.Itoa(opts.MaxTurns))
+	}
+	if len(opts.AllowedTools) > 0 {
+		args = append(args, "--allowedTools", strings.Join(opts.AllowedTools, ","))
+	}
+	if len(opts.DisallowedTools) > 0 {
+		args = append(args, "--disallowedTools", strings.Join(opts.DisallowedTools, ","))
+	}
+	if opts.AppendSystemPrompt != "" {
+		args = append(args, "--append-system-prompt", opts.AppendSystemPrompt)
+	}
+	return args
+}
+
+// RunClaudeWith is RunClaude with explicit options.
+func RunClaudeWith(ctx context.Context, prompt, workDir string, opts RunOptions) (*ExecutionResult, error) {
+	pre, err := SnapshotWorkspace(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting workspace: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, "claude", claudeArgs(prompt, opts)...)
+	cmd.Dir = workDir
+	cmd.Env = opts.environ()
+
+	start := time.Now()
+	out, err := cmd.Output()
+	elapsed := time.Since(start)
+	if err != nil {
+		if ctx.Err() != nil {
+			// The deadline fired and claude was killed mid-run. Salvage
+			// whatever events made it out so the caller can still save
+			// the transcript and report partial stats.
+			partial := &ExecutionResult{
+				Events:      parsePartialClaudeOutput(out),
+				Raw:         out,
+				Duration:    elapsed,
+				PreSnapshot: pre,
+			}
+			return partial, fmt.Errorf("claude run cut short: %w", ctx.Err())
+		}
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("claude exited with %v: %s", ee.ExitCode(), ee.Stderr)
+		}
+		return nil, err
+	}
+
+	events, err := parseClaudeOutput(out)
+	if err != nil {
+		return nil, fmt.Errorf("parsing claude output: %w", err)
+	}
+	post, err := SnapshotWorkspace(workDir)
+	if err != nil {
+		return nil, fmt.Errorf("snapshotting workspace: %w", err)
+	}
+	return &ExecutionResult{Events: events, Raw: out, Duration: elapsed, PreSnapshot: pre, PostSnapshot: post}, nil
+}
+
+// ModelRun is the outcome of one model in a matrix run.
+type ModelRun struct {
+	Model  string
+	Result *ExecutionResult
+	Err    error
+}
+
+// RunAcrossModels invokes run once per model and pairs each outcome with
+// its model, so the same eval can measure skill quality on haiku, sonnet
+// and opus in one pass. run typically wraps RunClaudeWith with a fresh
+// workdir per model.
+func RunAcrossModels(ctx context.Context, models []string, run func(ctx context.Context, model string) (*ExecutionResult, error)) []ModelRun {
+	runs := make([]ModelRun, 0, len(models))
+	for _, model := range models {
+		result, err := run(ctx, model)
+		runs = append(runs, ModelRun{Model: model, Result: result, Err: err})
+	}
+	return runs
+}
+
+// RunClaudeStreaming is RunClaude over the stream-json transport:
+// events are decoded incrementally instead of buffering one JSON blob
+// in memory, and progress (current tool, turn count, cost so far) is
+// logged to t as it happens, so long runs are not a black box until
+// they finish.
+func RunClaudeStreaming(ctx context.Context, t TB, prompt, workDir string) (*ExecutionResult, error) {
+	runner := &SDKRunner{OnEvent: ProgressLogger(t)}
+	return runner.Run(ctx, prompt, workDir)
+}
+
+// ProgressLogger returns an OnEvent callback that narrates a streaming
+// run to the test log.
+func ProgressLogger(t TB) func(ClaudeEvent) {
+	turns := 0
+	var cost float64
+	return func(ev ClaudeEvent) {
+		if ev.TotalCostUSD > 0 {
+			cost = ev.TotalCostUSD
+		}
+		switch ev.Type {
+		case "assistant":
+			turns++
+			if ev.Message == nil {
+				return
+			}
+			for _, block := range ev.Message.Content {
+				if block.Type == "tool_use" {
+					t.Logf("turn %d: %s ($%.4f so far)", turns, block.Name, cost)
+				}
+			}
+		case "result":
+			t.Logf("run finished after %d turns: $%.4f", turns, ev.TotalCostUSD)
+		}
+	}
+}
+
+func parseClaudeOutput(out []byte) ([]ClaudeEvent, error) {
+	var events []ClaudeEvent
+	if err := json.Unmarshal(out, &events); err == nil {
+		return events, nil
+	}
+	var single ClaudeEvent
+	if err := json.Unmarshal(out, &single); err != nil {
+		return nil, err
+	}
+	return []ClaudeEvent{single}, nil
+}
+
+// parsePartialClaudeOutput recovers what it can from a truncated
+// transcript: first as a complete document, then event by event per
+// line, dropping whatever the kill cut mid-write.
+func parsePartialClaudeOutput(out []byte) []ClaudeEvent {
+	if events, err := parseClaudeOutput(out); err == nil {
+		return events
+	}
+	var events []ClaudeEvent
+	for _, line := range strings.Split(string(out), "\n") {
+		line = strings.TrimSuffix(strings.TrimSpace(line), ",")
+		var ev ClaudeEvent
+		if err := json.Unmarshal([]byte(line), &ev); err == nil && ev.Type != "" {
+			events = append(events, ev)
+		}
+	}
+	return events
+}
+
+// LoadTranscript reads a previously saved raw transcript back into an
+// ExecutionResult for offline triage. Duration is not recoverable from
+// the raw events beyond what the result event reports.
+func LoadTranscript(path string) (*ExecutionResult, error) {
+	raw, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	events, err := parseClaudeOutput(raw)
+	if err != nil {
+		return nil, fmt.Errorf("parsing transcript %s: %w", path, err)
+	}
+	result := &ExecutionResult{Events: events, Raw: raw}
+	if res := result.ResultEvent(); res != nil {
+		result.Duration = time.Duration(res.DurationMS) * time.Millisecond
+	}
+	return result, nil
+}
+
+// PluginDir returns the path to the rwx plugin in this repository, so
+// evals always exercise the working-tree skills rather than an installed
+// copy.
+func PluginDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "plugins", "rwx")
+}
+
+// InitEvent returns the system/init event describing what the harness
+// loaded at startup, or nil if the transcript has none.
+func (r *ExecutionResult) InitEvent() *ClaudeEvent {
+	for i := range r.Events {
+		if r.Events[i].Type == "system" && r.Events[i].Subtype == "init" {
+			return &r.Events[i]
+		}
+	}
+	return nil
+}
+
+// RegisteredSkills returns the slash commands the harness registered at
+// startup, including plugin-provided skills. Use it to verify plugin-dir
+// wiring before asserting the agent used a skill.
+func (r *ExecutionResult) RegisteredSkills() []string {
+	if init := r.InitEvent(); init != nil {
+		return init.SlashCommands
+	}
+	return nil
+}
+
+// PluginLoaded reports whether the init event shows a plugin with the
+// given name was loaded.
+func (r *ExecutionResult) PluginLoaded(name string) bool {
+	init := r.InitEvent()
+	if init == nil {
+		return false
+	}
+	for _, plugin := range init.Plugins {
+		if plugin.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// PluginVersions returns the version of each loaded plugin by name, from
+// the init event. Plugins that report no version are omitted.
+func (r *ExecutionResult) PluginVersions() map[string]string {
+	init := r.InitEvent()
+	if init == nil {
+		return nil
+	}
+	var versions map[string]string
+	for _, plugin := range init.Plugins {
+		if plugin.Version == "" {
+			continue
+		}
+		if versions == nil {
+			versions = map[string]string{}
+		}
+		versions[plugin.Name] = plugin.Version
+	}
+	return versions
+}
+
+// ResultEvent returns the final result event, or nil if the run produced
+// none (e.g. the process was killed).
+func (r *ExecutionResult) ResultEvent() *ClaudeEvent {
+	for i := range r.Events {
+		if r.Events[i].Type == "result" {
+			return &r.Events[i]
+		}
+	}
+	return nil
+}
+
+// ToolsUsed returns the unique tool names invoked during the run, in
+// first-use order.
+func (r *ExecutionResult) ToolsUsed() []string {
+	var tools []string
+	seen := map[string]bool{}
+	for _, ev := range r.Events {
+		if ev.Message == nil {
+			continue
+		}
+		for _, block := range ev.Message.Content {
+			if block.Type == "tool_use" && !seen[block.Name] {
+				seen[block.Name] = true
+				tools = append(tools, block.Name)
+			}
+		}
+	}
+	return tools
+}
+
+// SkillsUsed returns the skill commands invoked via the Skill tool, in
+// first-use order.
+func (r *ExecutionResult) SkillsUsed() []string {
+	var skills []string
+	seen := map[string]bool{}
+	for _, ev := range r.Events {
+		if ev.Message == nil {
+			continue
+		}
+		for _, block := range ev.Message.Content {
+			if block.Type != "tool_use" || block.Name != "Skill" {
+				continue
+			}
+			var input struct {
+				Command string `json:"command"`
+			}
+			if err := json.Unmarshal(block.Input, &input); err != nil || input.Command == "" {
+				continue
+			}
+			if !seen[input.Command] {
+				seen[input.Command] = true
+				skills = append(skills, input.Command)
+			}
+		}
+	}
+	return skills
+}
+
+// TotalTokens returns the total input+output tokens reported by the
+// result event, falling back to summing assistant message usage.
+func (r *ExecutionResult) TotalTokens() int {
+	if res := r.ResultEvent(); res != nil && res.Usage != nil {
+		return res.Usage.InputTokens + res.Usage.OutputTokens
+	}
+	total := 0
+	for _, ev := range r.Events {
+		if ev.Message != nil && ev.Message.Usage != nil {
+			total += ev.Message.Usage.InputTokens + ev.Message.Usage.OutputTokens
+		}
+	}
+	return total
+}
+
+// OnlyToolsUsed fails when the transcript shows any tool outside
+// allowed. Use it to verify a skill works without WebSearch/WebFetch and
+// never reaches for tools outside the sandbox; pair with
+// RunOptions.DisallowedTools to enforce the same at run time.
+func OnlyToolsUsed(t TB, result *ExecutionResult, allowed ...string) {
+	t.Helper()
+	permitted := make(map[string]bool, len(allowed))
+	for _, name := range allowed {
+		permitted[name] = true
+	}
+	for _, name := range result.ToolsUsed() {
+		if !permitted[name] {
+			t.Errorf("tool %q was used but is not in the allowed set %v", name, allowed)
+		}
+	}
+}
+
+// PermissionDenials returns the tool calls that were blocked by
+// permission prompts during the run, from the result event.
+func (r *ExecutionResult) PermissionDenials() []PermissionDenial {
+	if res := r.ResultEvent(); res != nil {
+		return res.PermissionDenials
+	}
+	return nil
+}
+
+// NoPermissionDenials fails when any tool call was blocked by a
+// permission prompt. Runs without --dangerously-skip-permissions
+// otherwise fail opaquely downstream, on whatever the blocked tool was
+// supposed to produce.
+func NoPermissionDenials(t TB, result *ExecutionResult) {
+	t.Helper()
+	for _, denial := range result.PermissionDenials() {
+		t.Errorf("tool %q was blocked by a permission prompt (input: %s)", denial.ToolName, denial.ToolInput)
+	}
+}
+
+// eachToolUse invokes fn for every tool_use block in transcript order.
+func (r *ExecutionResult) eachToolUse(fn func(block ContentBlock)) {
+	for _, ev := range r.Events {
+		if ev.Message == nil {
+			continue
+		}
+		for _, block := range ev.Message.Content {
+			if block.Type == "tool_use" {
+				fn(block)
+			}
+		}
+	}
+}
+
+// ToolCallCount returns the total number of tool invocations in the run.
+func (r *ExecutionResult) ToolCallCount() int {
+	n := 0
+	r.eachToolUse(func(ContentBlock) { n++ })
+	return n
+}
+
+// ToolCallCounts returns the number of invocations per tool, unlike
+// ToolsUsed which only records that a tool was reached for at all.
+func (r *ExecutionResult) ToolCallCounts() map[string]int {
+	counts := map[string]int{}
+	r.eachToolUse(func(block ContentBlock) {
+		counts[block.Name]++
+	})
+	return counts
+}
+
+// BashCommands returns the command of every Bash tool call, in order, so
+// assertions like "never ran rm -rf" don't re-unmarshal raw inputs.
+func (r *ExecutionResult) BashCommands() []string {
+	var commands []string
+	r.eachToolUse(func(block ContentBlock) {
+		if block.Name != "Bash" {
+			return
+		}
+		var input struct {
+			Command string `json:"command"`
+		}
+		if err := json.Unmarshal(block.Input, &input); err == nil && input.Command != "" {
+			commands = append(commands, input.Command)
+		}
+	})
+	return commands
+}
+
+// FilesWritten returns the unique paths targeted by Write and Edit tool
+// calls, in first-write order, enabling assertions like "wrote only
+// under .rwx/".
+func (r *ExecutionResult) FilesWritten() []string {
+	var paths []string
+	seen := map[string]bool{}
+	r.eachToolUse(func(block ContentBlock) {
+		if block.Name != "Write" && block.Name != "Edit" {
+			return
+		}
+		var input struct {
+			FilePath string `json:"file_path"`
+		}
+		if err := json.Unmarshal(block.Input, &input); err != nil || input.FilePath == "" {
+			return
+		}
+		if !seen[input.FilePath] {
+			seen[input.FilePath] = true
+			paths = append(paths, input.FilePath)
+		}
+	})
+	return paths
+}
+
+// ToolResult is one tool_result block from the transcript: the outcome
+// the agent saw after a tool call.
+type ToolResult struct {
+	ToolUseID string
+	IsError   bool
+	Text      string
+}
+
+// ToolResults returns every tool_result in transcript order, so evals
+// can assert on command failures the agent encountered and whether it
+// recovered.
+func (r *ExecutionResult) ToolResults() []ToolResult {
+	var results []ToolResult
+	for _, ev := range r.Events {
+		if ev.Message == nil {
+			continue
+		}
+		for _, block := range ev.Message.Content {
+			if block.Type != "tool_result" {
+				continue
+			}
+			results = append(results, ToolResult{
+				ToolUseID: block.ToolUseID,
+				IsError:   block.IsError,
+				Text:      toolResultText(block.Content),
+			})
+		}
+	}
+	return results
+}
+
+// toolResultText flattens a tool_result content payload, which the API
+// sends either as a plain string or as a list of text blocks.
+func toolResultText(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+	var text string
+	if err := json.Unmarshal(raw, &text); err == nil {
+		return text
+	}
+	var blocks []ContentBlock
+	if err := json.Unmarshal(raw, &blocks); err != nil {
+		return string(raw)
+	}
+	var parts []string
+	for _, block := range blocks {
+		if block.Text != "" {
+			parts = append(parts, block.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// ThinkingBlocks returns the thinking content in transcript order.
+func (r *ExecutionResult) ThinkingBlocks() []string {
+	var blocks []string
+	for _, ev := range r.Events {
+		if ev.Message == nil {
+			continue
+		}
+		for _, block := range ev.Message.Content {
+			if block.Type == "thinking" && block.Thinking != "" {
+				blocks = append(blocks, block.Thinking)
+			}
+		}
+	}
+	return blocks
+}
+
+// ThinkingTokens estimates the tokens spent thinking (~4 chars/token).
+// The API folds thinking into output tokens without separate accounting,
+// so this is an estimate — but it moves with real deliberation, which is
+// what the regression check cares about.
+func (r *ExecutionResult) ThinkingTokens() int {
+	chars := 0
+	for _, block := range r.ThinkingBlocks() {
+		chars += len(block)
+	}
+	return chars / 4
+}
+
+// NumTurns returns the turn count reported by the result event, falling
+// back to counting assistant events for transcripts recorded before the
+// field existed.
+func (r *ExecutionResult) NumTurns() int {
+	if res := r.ResultEvent(); res != nil && res.NumTurns > 0 {
+		return res.NumTurns
+	}
+	turns := 0
+	for _, ev := range r.Events {
+		if ev.Type == "assistant" {
+			turns++
+		}
+	}
+	return turns
+}
+
+// CostUSD returns the total cost reported by the result event.
+func (r *ExecutionResult) CostUSD() float64 {
+	if res := r.ResultEvent(); res != nil {
+		return res.TotalCostUSD
+	}
+	return 0
+}
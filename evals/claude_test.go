@@ -0,0 +1,304 @@
+package evals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRunOptionsModel(t *testing.T) {
+	t.Setenv("EVALS_MODEL", "")
+	if got := (RunOptions{}).model(); got != "sonnet" {
+		t.Errorf("default model = %q", got)
+	}
+	t.Setenv("EVALS_MODEL", "haiku")
+	if got := (RunOptions{}).model(); got != "haiku" {
+		t.Errorf("env model = %q", got)
+	}
+	if got := (RunOptions{Model: "opus"}).model(); got != "opus" {
+		t.Errorf("explicit model = %q", got)
+	}
+}
+
+func TestRunOptionsEnviron(t *testing.T) {
+	if env := (RunOptions{}).environ(); env != nil {
+		t.Errorf("empty Env should inherit (nil), got %d entries", len(env))
+	}
+
+	env := (RunOptions{Env: map[string]string{
+		"RWX_ACCESS_TOKEN": "fake-token",
+		"GIT_AUTHOR_NAME":  "evals",
+	}}).environ()
+	if len(env) < 2 {
+		t.Fatalf("environ too short: %v", env)
+	}
+	tail := env[len(env)-2:]
+	if tail[0] != "GIT_AUTHOR_NAME=evals" || tail[1] != "RWX_ACCESS_TOKEN=fake-token" {
+		t.Errorf("overrides = %v", tail)
+	}
+}
+
+func TestClaudeArgs(t *testing.T) {
+	args := claudeArgs("do it", RunOptions{
+		Model:              "haiku",
+		MaxTurns:           12,
+		AllowedTools:       []string{"Read", "Write"},
+		DisallowedTools:    []string{"WebSearch"},
+		AppendSystemPrompt: "prefer filters on every task",
+	})
+	joined := strings.Join(args, " ")
+	for _, want := range []string{
+		"--model haiku",
+		"--max-turns 12",
+		"--allowedTools Read,Write",
+		"--disallowedTools WebSearch",
+		"--append-system-prompt prefer filters on every task",
+	} {
+		if !strings.Contains(joined, want) {
+			t.Errorf("args missing %q: %v", want, args)
+		}
+	}
+
+	t.Setenv("EVALS_MODEL", "")
+	joined = strings.Join(claudeArgs("do it", RunOptions{}), " ")
+	for _, flag := range []string{"--max-turns", "--allowedTools", "--disallowedTools", "--append-system-prompt"} {
+		if strings.Contains(joined, flag) {
+			t.Errorf("zero options should not emit %s: %v", flag, joined)
+		}
+	}
+}
+
+func TestOnlyToolsUsed(t *testing.T) {
+	result := &ExecutionResult{Events: []ClaudeEvent{
+		{Type: "assistant", Message: &ClaudeMessage{Content: []ContentBlock{
+			{Type: "tool_use", Name: "Read"},
+			{Type: "tool_use", Name: "Write"},
+		}}},
+		{Type: "assistant", Message: &ClaudeMessage{Content: []ContentBlock{
+			{Type: "tool_use", Name: "WebSearch"},
+		}}},
+	}}
+
+	probe := &probeTB{}
+	OnlyToolsUsed(probe, result, "Read", "Write", "Bash")
+	if !probe.failed {
+		t.Fatal("WebSearch should fail the allowlist")
+	}
+	if !strings.Contains(probe.messages[0], "WebSearch") {
+		t.Errorf("message = %q", probe.messages[0])
+	}
+
+	probe = &probeTB{}
+	OnlyToolsUsed(probe, result, "Read", "Write", "WebSearch")
+	if probe.failed {
+		t.Errorf("all tools allowed, but failed: %v", probe.messages)
+	}
+}
+
+func TestBashCommandsAndFilesWritten(t *testing.T) {
+	raw := `[
+	  {"type":"assistant","message":{"role":"assistant","content":[
+	    {"type":"tool_use","name":"Bash","input":{"command":"go test ./..."}},
+	    {"type":"tool_use","name":"Write","input":{"file_path":".rwx/ci.yml","content":"tasks: []"}}
+	  ]}},
+	  {"type":"assistant","message":{"role":"assistant","content":[
+	    {"type":"tool_use","name":"Edit","input":{"file_path":".rwx/ci.yml"}},
+	    {"type":"tool_use","name":"Bash","input":{"command":"cat .rwx/ci.yml"}},
+	    {"type":"tool_use","name":"Read","input":{"file_path":"go.mod"}}
+	  ]}},
+	  {"type":"result","subtype":"success"}
+	]`
+	events, err := parseClaudeOutput([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := &ExecutionResult{Events: events}
+
+	commands := result.BashCommands()
+	if len(commands) != 2 || commands[0] != "go test ./..." || commands[1] != "cat .rwx/ci.yml" {
+		t.Errorf("BashCommands = %v", commands)
+	}
+	files := result.FilesWritten()
+	if len(files) != 1 || files[0] != ".rwx/ci.yml" {
+		t.Errorf("FilesWritten = %v", files)
+	}
+	if got := result.ToolCallCount(); got != 5 {
+		t.Errorf("ToolCallCount = %d", got)
+	}
+	counts := result.ToolCallCounts()
+	if counts["Bash"] != 2 || counts["Write"] != 1 || counts["Edit"] != 1 || counts["Read"] != 1 {
+		t.Errorf("ToolCallCounts = %v", counts)
+	}
+}
+
+func TestToolResults(t *testing.T) {
+	raw := `[
+	  {"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"tu_1","name":"Bash","input":{"command":"go test ./..."}}]}},
+	  {"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"tu_1","is_error":true,"content":"exit status 1"}]}},
+	  {"type":"user","message":{"role":"user","content":[{"type":"tool_result","tool_use_id":"tu_2","content":[{"type":"text","text":"ok"},{"type":"text","text":"done"}]}]}},
+	  {"type":"result","subtype":"success"}
+	]`
+	events, err := parseClaudeOutput([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := &ExecutionResult{Events: events}
+
+	results := result.ToolResults()
+	if len(results) != 2 {
+		t.Fatalf("got %d tool results: %+v", len(results), results)
+	}
+	if !results[0].IsError || results[0].Text != "exit status 1" || results[0].ToolUseID != "tu_1" {
+		t.Errorf("first = %+v", results[0])
+	}
+	if results[1].IsError || results[1].Text != "ok\ndone" {
+		t.Errorf("second = %+v", results[1])
+	}
+}
+
+func TestNumTurns(t *testing.T) {
+	reported := resultWith(ClaudeEvent{Type: "result", Subtype: "success", NumTurns: 7})
+	if got := reported.NumTurns(); got != 7 {
+		t.Errorf("NumTurns = %d, want 7", got)
+	}
+	counted := &ExecutionResult{Events: []ClaudeEvent{
+		{Type: "assistant"},
+		{Type: "user"},
+		{Type: "assistant"},
+		{Type: "result", Subtype: "success"},
+	}}
+	if got := counted.NumTurns(); got != 2 {
+		t.Errorf("fallback NumTurns = %d, want 2", got)
+	}
+}
+
+func TestThinkingBlocks(t *testing.T) {
+	raw := `[
+	  {"type":"assistant","message":{"role":"assistant","content":[
+	    {"type":"thinking","thinking":"The workflow has a matrix, so parallel values are needed."},
+	    {"type":"text","text":"I'll set up the config."}
+	  ]}},
+	  {"type":"assistant","message":{"role":"assistant","content":[
+	    {"type":"thinking","thinking":"Done."}
+	  ]}},
+	  {"type":"result","subtype":"success"}
+	]`
+	events, err := parseClaudeOutput([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := &ExecutionResult{Events: events}
+
+	blocks := result.ThinkingBlocks()
+	if len(blocks) != 2 || blocks[1] != "Done." {
+		t.Errorf("ThinkingBlocks = %v", blocks)
+	}
+	// 57 + 5 chars of thinking at ~4 chars/token.
+	if got := result.ThinkingTokens(); got != 15 {
+		t.Errorf("ThinkingTokens = %d, want 15", got)
+	}
+}
+
+func TestInitEventSkills(t *testing.T) {
+	raw := `[
+	  {"type":"system","subtype":"init","tools":["Bash","Read","Skill"],"slash_commands":["rwx:migrate-gha","compact"],"plugins":[{"name":"rwx","version":"1.4.0","path":"/plugins/rwx"},{"name":"bare","path":"/plugins/bare"}]},
+	  {"type":"result","subtype":"success"}
+	]`
+	events, err := parseClaudeOutput([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := &ExecutionResult{Events: events}
+
+	skills := result.RegisteredSkills()
+	if len(skills) != 2 || skills[0] != "rwx:migrate-gha" {
+		t.Errorf("RegisteredSkills = %v", skills)
+	}
+	if !result.PluginLoaded("rwx") {
+		t.Error("PluginLoaded(rwx) = false")
+	}
+	if result.PluginLoaded("other") {
+		t.Error("PluginLoaded(other) = true")
+	}
+	versions := result.PluginVersions()
+	if len(versions) != 1 || versions["rwx"] != "1.4.0" {
+		t.Errorf("PluginVersions = %v", versions)
+	}
+
+	empty := resultWith(ClaudeEvent{Type: "result", Subtype: "success"})
+	if empty.InitEvent() != nil || empty.RegisteredSkills() != nil || empty.PluginLoaded("rwx") {
+		t.Error("transcript without init event should report nothing registered")
+	}
+}
+
+func TestParsePartialClaudeOutput(t *testing.T) {
+	truncated := []byte(`[
+	  {"type":"system","subtype":"init"},
+	  {"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash","input":{"command":"go test"}}]}},
+	  {"type":"assistant","message":{"role":"assi`)
+	events := parsePartialClaudeOutput(truncated)
+	if len(events) != 2 {
+		t.Fatalf("recovered %d events, want 2: %+v", len(events), events)
+	}
+	result := &ExecutionResult{Events: events}
+	if got := result.ToolsUsed(); len(got) != 1 || got[0] != "Bash" {
+		t.Errorf("ToolsUsed from partial = %v", got)
+	}
+
+	complete := []byte(`[{"type":"result","subtype":"success"}]`)
+	if events := parsePartialClaudeOutput(complete); len(events) != 1 {
+		t.Errorf("complete transcript should parse wholesale: %+v", events)
+	}
+}
+
+func TestPermissionDenials(t *testing.T) {
+	raw := `[
+	  {"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","id":"tu_1","name":"Bash","input":{"command":"rm -rf build"}}]}},
+	  {"type":"result","subtype":"success","permission_denials":[{"tool_name":"Bash","tool_use_id":"tu_1","tool_input":{"command":"rm -rf build"}}]}
+	]`
+	events, err := parseClaudeOutput([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := &ExecutionResult{Events: events}
+
+	denials := result.PermissionDenials()
+	if len(denials) != 1 || denials[0].ToolName != "Bash" || denials[0].ToolUseID != "tu_1" {
+		t.Fatalf("PermissionDenials = %+v", denials)
+	}
+
+	probe := &probeTB{}
+	NoPermissionDenials(probe, result)
+	if !probe.failed {
+		t.Fatal("denial should fail the assertion")
+	}
+	if !strings.Contains(probe.messages[0], "Bash") {
+		t.Errorf("message = %q", probe.messages[0])
+	}
+
+	probe = &probeTB{}
+	NoPermissionDenials(probe, resultWith(ClaudeEvent{Type: "result", Subtype: "success"}))
+	if probe.failed {
+		t.Errorf("clean run failed: %v", probe.messages)
+	}
+}
+
+func TestRunAcrossModels(t *testing.T) {
+	runs := RunAcrossModels(context.Background(), []string{"haiku", "sonnet", "opus"},
+		func(ctx context.Context, model string) (*ExecutionResult, error) {
+			if model == "opus" {
+				return nil, fmt.Errorf("no capacity")
+			}
+			return &ExecutionResult{Events: []ClaudeEvent{{Type: "result", Subtype: "success"}}}, nil
+		})
+	if len(runs) != 3 {
+		t.Fatalf("got %d runs", len(runs))
+	}
+	if runs[0].Model != "haiku" || runs[0].Err != nil || runs[0].Result == nil {
+		t.Errorf("haiku run = %+v", runs[0])
+	}
+	if runs[2].Model != "opus" || runs[2].Err == nil {
+		t.Errorf("opus run = %+v", runs[2])
+	}
+}
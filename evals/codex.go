@@ -0,0 +1,129 @@
+package evals
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// CodexRunner runs the OpenAI codex CLI in its non-interactive exec mode
+// and adapts the JSONL output into an ExecutionResult, so the same
+// fixtures and assertions can benchmark other agents against our skills.
+// Codex has no plugin mechanism; skills must be surfaced to it through
+// the fixture (e.g. an AGENTS.md pointing at the skill files).
+type CodexRunner struct {
+	// Model selects the model via --model; empty uses the CLI default.
+	Model string
+	// Command overrides the executable, for tests. Defaults to "codex".
+	Command string
+}
+
+// codexEvent is one line of `codex exec --json` output. Only the fields
+// the translation needs are modeled.
+type codexEvent struct {
+	Msg struct {
+		Type    string   `json:"type"`
+		Message string   `json:"message"`
+		Command []string `json:"command"`
+		Info    struct {
+			TotalTokenUsage struct {
+				InputTokens  int `json:"input_tokens"`
+				OutputTokens int `json:"output_tokens"`
+			} `json:"total_token_usage"`
+		} `json:"info"`
+	} `json:"msg"`
+}
+
+// Run implements AgentRunner.
+func (r *CodexRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	command := r.Command
+	if command == "" {
+		command = "codex"
+	}
+	args := []string{"exec", "--json", "--skip-git-repo-check", "--sandbox", "workspace-write"}
+	if r.Model != "" {
+		args = append(args, "--model", r.Model)
+	}
+	args = append(args, prompt)
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = workDir
+
+	start := time.Now()
+	out, err := cmd.Output()
+	elapsed := time.Since(start)
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("codex exited with %v: %s", ee.ExitCode(), ee.Stderr)
+		}
+		return nil, err
+	}
+
+	events := translateCodexOutput(out, elapsed)
+	return &ExecutionResult{Events: events, Raw: out, Duration: elapsed}, nil
+}
+
+// translateCodexOutput maps codex JSONL events onto the ClaudeEvent
+// shapes the assertions and metrics understand: agent messages become
+// assistant text, command executions become Bash tool_use blocks, and
+// the final token count becomes the result event's usage.
+func translateCodexOutput(out []byte, elapsed time.Duration) []ClaudeEvent {
+	var events []ClaudeEvent
+	var usage ClaudeUsage
+	for _, line := range bytes.Split(out, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		var ev codexEvent
+		if err := json.Unmarshal(line, &ev); err != nil {
+			continue
+		}
+		switch ev.Msg.Type {
+		case "agent_message":
+			events = append(events, ClaudeEvent{
+				Type: "assistant",
+				Message: &ClaudeMessage{
+					Role:    "assistant",
+					Content: []ContentBlock{{Type: "text", Text: ev.Msg.Message}},
+				},
+			})
+		case "exec_command_begin":
+			input, _ := json.Marshal(map[string]string{"command": codexCommandString(ev.Msg.Command)})
+			events = append(events, ClaudeEvent{
+				Type: "assistant",
+				Message: &ClaudeMessage{
+					Role:    "assistant",
+					Content: []ContentBlock{{Type: "tool_use", Name: "Bash", Input: input}},
+				},
+			})
+		case "token_count":
+			usage.InputTokens = ev.Msg.Info.TotalTokenUsage.InputTokens
+			usage.OutputTokens = ev.Msg.Info.TotalTokenUsage.OutputTokens
+		case "task_complete":
+			events = append(events, ClaudeEvent{
+				Type:       "result",
+				Subtype:    "success",
+				DurationMS: elapsed.Milliseconds(),
+				Usage:      &usage,
+			})
+		}
+	}
+	return events
+}
+
+// codexCommandString flattens codex's argv form into the single command
+// string BashCommands and the command assertions expect; leaving it as an
+// array would make every command check pass vacuously. The common shell
+// wrapper unwraps to the script itself.
+func codexCommandString(argv []string) string {
+	if len(argv) == 3 && (argv[0] == "sh" || argv[0] == "bash") && argv[1] == "-c" {
+		return argv[2]
+	}
+	return strings.Join(argv, " ")
+}
+
+var _ AgentRunner = (*CodexRunner)(nil)
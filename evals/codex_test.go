@@ -0,0 +1,60 @@
+package evals
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCodexRunner(t *testing.T) {
+	fake := filepath.Join(t.TempDir(), "fake-codex")
+	script := `#!/bin/sh
+echo '{"msg":{"type":"task_started"}}'
+echo '{"msg":{"type":"exec_command_begin","command":["go","test","./..."]}}'
+echo '{"msg":{"type":"agent_message","message":"created .rwx/ci.yml"}}'
+echo '{"msg":{"type":"token_count","info":{"total_token_usage":{"input_tokens":120,"output_tokens":40}}}}'
+echo '{"msg":{"type":"task_complete"}}'
+`
+	if err := os.WriteFile(fake, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := &CodexRunner{Command: fake}
+	result, err := runner.Run(context.Background(), "migrate this repo", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Events) != 3 {
+		t.Fatalf("got %d events: %+v", len(result.Events), result.Events)
+	}
+	if got := result.ToolsUsed(); len(got) != 1 || got[0] != "Bash" {
+		t.Errorf("ToolsUsed = %v", got)
+	}
+	if got := result.BashCommands(); len(got) != 1 || got[0] != "go test ./..." {
+		t.Errorf("BashCommands = %v", got)
+	}
+	if result.ResultEvent() == nil {
+		t.Fatal("no result event")
+	}
+	if got := result.TotalTokens(); got != 160 {
+		t.Errorf("TotalTokens = %d, want 160", got)
+	}
+}
+
+func TestCodexCommandString(t *testing.T) {
+	if got := codexCommandString([]string{"sh", "-c", "rm -rf /tmp/x && go test ./..."}); got != "rm -rf /tmp/x && go test ./..." {
+		t.Errorf("sh -c unwrap = %q", got)
+	}
+	if got := codexCommandString([]string{"go", "test", "./..."}); got != "go test ./..." {
+		t.Errorf("argv join = %q", got)
+	}
+}
+
+func TestTranslateCodexOutputSkipsGarbage(t *testing.T) {
+	out := []byte("not json\n{\"msg\":{\"type\":\"agent_message\",\"message\":\"hi\"}}\n")
+	events := translateCodexOutput(out, 0)
+	if len(events) != 1 || events[0].Message.Content[0].Text != "hi" {
+		t.Errorf("events = %+v", events)
+	}
+}
@@ -0,0 +1,53 @@
+package evals
+
+import (
+	"os"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// maxConcurrency returns how many agent runs may be in flight at once,
+// from EVALS_MAX_CONCURRENCY. The default of 2 keeps a laptop run
+// responsive while still halving wall-clock time versus serial.
+func maxConcurrency() int {
+	raw := os.Getenv("EVALS_MAX_CONCURRENCY")
+	if raw == "" {
+		return 2
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 2
+	}
+	return n
+}
+
+var (
+	evalSlotsOnce sync.Once
+	evalSlots     chan struct{}
+)
+
+// acquireEvalSlot blocks until a run slot is free and returns a release
+// function. The slot count is fixed on first use.
+func acquireEvalSlot() (release func()) {
+	evalSlotsOnce.Do(func() {
+		evalSlots = make(chan struct{}, maxConcurrency())
+	})
+	evalSlots <- struct{}{}
+	return func() { <-evalSlots }
+}
+
+// ParallelEval marks the test parallel and throttles it against the
+// run-wide concurrency limit and budget. Evals are serial by default;
+// with fifteen-minute runs that makes a full suite take hours, so evals
+// opt in here and go test's own -parallel stays at its default.
+//
+// The budget is re-checked after the slot is acquired: evals that ran
+// while this one waited may have already spent the remaining cap.
+func ParallelEval(t *testing.T) {
+	t.Helper()
+	t.Parallel()
+	release := acquireEvalSlot()
+	t.Cleanup(release)
+	SkipIfOverTotalBudget(t)
+}
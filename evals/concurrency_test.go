@@ -0,0 +1,47 @@
+package evals
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestMaxConcurrency(t *testing.T) {
+	t.Setenv("EVALS_MAX_CONCURRENCY", "")
+	if got := maxConcurrency(); got != 2 {
+		t.Errorf("default = %d", got)
+	}
+	t.Setenv("EVALS_MAX_CONCURRENCY", "8")
+	if got := maxConcurrency(); got != 8 {
+		t.Errorf("env = %d", got)
+	}
+	t.Setenv("EVALS_MAX_CONCURRENCY", "0")
+	if got := maxConcurrency(); got != 2 {
+		t.Errorf("invalid should fall back: %d", got)
+	}
+}
+
+func TestAcquireEvalSlot(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			release := acquireEvalSlot()
+			defer release()
+			n := inFlight.Add(1)
+			for {
+				p := peak.Load()
+				if n <= p || peak.CompareAndSwap(p, n) {
+					break
+				}
+			}
+			inFlight.Add(-1)
+		}()
+	}
+	wg.Wait()
+	if got := peak.Load(); got > int32(maxConcurrency()) {
+		t.Errorf("peak concurrency %d exceeds limit %d", got, maxConcurrency())
+	}
+}
@@ -0,0 +1,130 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// CorpusEntry is one generated config persisted to the corpus store,
+// normalized and annotated with where it came from.
+type CorpusEntry struct {
+	Eval       string    `json:"eval"`
+	RecordedAt time.Time `json:"recorded_at"`
+	ConfigYAML string    `json:"config_yaml"`
+}
+
+// Config parses the stored YAML back into a config.
+func (e CorpusEntry) Config() (*RWXConfig, error) {
+	return ParseRWXConfig([]byte(e.ConfigYAML))
+}
+
+// Corpus is a directory of every config the evals have seen agents
+// generate. Querying it answers questions like "how often do agents use
+// rwx/tool-versions?" that drive data-driven skill improvements, and
+// re-assert mode can sweep it.
+type Corpus struct {
+	Dir string
+}
+
+// OpenCorpus returns the corpus at dir, creating it on first save.
+func OpenCorpus(dir string) *Corpus {
+	return &Corpus{Dir: dir}
+}
+
+// Save normalizes and persists a generated config under the eval's name.
+func (c *Corpus) Save(eval string, cfg *RWXConfig) (string, error) {
+	rendered, err := cfg.Normalize().Render()
+	if err != nil {
+		return "", err
+	}
+	entry := CorpusEntry{
+		Eval:       eval,
+		RecordedAt: time.Now().UTC(),
+		ConfigYAML: rendered,
+	}
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return "", err
+	}
+	name := fmt.Sprintf("%s_%d.json", sanitizeName(eval), entry.RecordedAt.UnixNano())
+	path := filepath.Join(c.Dir, name)
+	return path, os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// Entries loads every stored corpus entry.
+func (c *Corpus) Entries() ([]CorpusEntry, error) {
+	paths, err := filepath.Glob(filepath.Join(c.Dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	var entries []CorpusEntry
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		var entry CorpusEntry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			return nil, fmt.Errorf("corpus entry %s: %w", path, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries, nil
+}
+
+// CallPrefixCounts returns how many corpus configs call each package,
+// counting a package once per config.
+func (c *Corpus) CallPrefixCounts() (map[string]int, error) {
+	counts := map[string]int{}
+	err := c.eachConfig(func(cfg *RWXConfig) {
+		seen := map[string]bool{}
+		for _, task := range cfg.Tasks {
+			if task.Call == "" {
+				continue
+			}
+			prefix, _, _ := strings.Cut(task.Call, " ")
+			if !seen[prefix] {
+				seen[prefix] = true
+				counts[prefix]++
+			}
+		}
+	})
+	return counts, err
+}
+
+// WithValueCounts returns the distribution of a with-param's values
+// across every corpus config calling the given package (e.g. which
+// go-versions get pinned on golang/install).
+func (c *Corpus) WithValueCounts(callPrefix, param string) (map[string]int, error) {
+	counts := map[string]int{}
+	err := c.eachConfig(func(cfg *RWXConfig) {
+		for _, task := range cfg.TasksCalling(callPrefix) {
+			if v, ok := task.With[param]; ok {
+				counts[v]++
+			}
+		}
+	})
+	return counts, err
+}
+
+func (c *Corpus) eachConfig(fn func(*RWXConfig)) error {
+	entries, err := c.Entries()
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		cfg, err := entry.Config()
+		if err != nil {
+			return fmt.Errorf("corpus entry for %s: %w", entry.Eval, err)
+		}
+		fn(cfg)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package evals
+
+import (
+	"testing"
+)
+
+func TestCorpusSaveAndQuery(t *testing.T) {
+	corpus := OpenCorpus(t.TempDir())
+
+	if _, err := corpus.Save("eval_a", mustParse(t, sampleConfig)); err != nil {
+		t.Fatal(err)
+	}
+	other := mustParse(t, `
+tasks:
+  - key: code
+    call: git/clone 2.0.3
+  - key: go
+    call: golang/install 1.2.0
+    with:
+      go-version: "1.26"
+  - key: versions
+    call: rwx/tool-versions 1.0.6
+`)
+	if _, err := corpus.Save("eval_b", other); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := corpus.Entries()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries", len(entries))
+	}
+	cfg, err := entries[0].Config()
+	if err != nil || len(cfg.Tasks) == 0 {
+		t.Fatalf("stored config unparseable: %v", err)
+	}
+
+	calls, err := corpus.CallPrefixCounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls["git/clone"] != 2 || calls["rwx/tool-versions"] != 1 {
+		t.Errorf("CallPrefixCounts = %v", calls)
+	}
+
+	versions, err := corpus.WithValueCounts("golang/install", "go-version")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if versions["1.22"] != 1 || versions["1.26"] != 1 {
+		t.Errorf("WithValueCounts = %v", versions)
+	}
+}
@@ -0,0 +1,106 @@
+package evals
+
+import (
+	"encoding/json"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+// CostEntry is one test's spend within a run.
+type CostEntry struct {
+	Test    string  `json:"test"`
+	CostUSD float64 `json:"cost_usd"`
+}
+
+// CostLedger accumulates spend across a test run. It is safe for
+// parallel tests.
+type CostLedger struct {
+	mu      sync.Mutex
+	entries []CostEntry
+	total   float64
+}
+
+// Record adds one run's cost under the given test name.
+func (l *CostLedger) Record(test string, costUSD float64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.entries = append(l.entries, CostEntry{Test: test, CostUSD: costUSD})
+	l.total += costUSD
+}
+
+// Total returns the spend recorded so far.
+func (l *CostLedger) Total() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.total
+}
+
+// Breakdown returns the recorded entries, most expensive first.
+func (l *CostLedger) Breakdown() []CostEntry {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	entries := make([]CostEntry, len(l.entries))
+	copy(entries, l.entries)
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].CostUSD > entries[j].CostUSD })
+	return entries
+}
+
+// WriteBreakdown writes the ledger as JSON: the total plus the per-test
+// entries, most expensive first.
+func (l *CostLedger) WriteBreakdown(path string) error {
+	doc := struct {
+		TotalUSD float64     `json:"total_usd"`
+		Tests    []CostEntry `json:"tests"`
+	}{TotalUSD: l.Total(), Tests: l.Breakdown()}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
+
+// runLedger is the package-level ledger fed by every eval run, written
+// out as a per-test cost breakdown in TestMain.
+var runLedger CostLedger
+
+// RecordCost adds a run's reported cost to the run-wide ledger.
+func RecordCost(test string, result *ExecutionResult) {
+	runLedger.Record(test, result.CostUSD())
+}
+
+// TotalCostUSD returns the spend recorded across the whole run so far.
+func TotalCostUSD() float64 {
+	return runLedger.Total()
+}
+
+// totalBudgetUSD returns the run-wide cap from EVALS_TOTAL_BUDGET_USD,
+// or 0 when unset.
+func totalBudgetUSD() float64 {
+	raw := os.Getenv("EVALS_TOTAL_BUDGET_USD")
+	if raw == "" {
+		return 0
+	}
+	budget, err := strconv.ParseFloat(raw, 64)
+	if err != nil {
+		return 0
+	}
+	return budget
+}
+
+// SkipIfOverTotalBudget skips the test when spend already recorded this
+// run has reached the EVALS_TOTAL_BUDGET_USD cap. Evals call it before
+// launching the agent, so one runaway suite cannot drain the budget for
+// everything scheduled after it without a visible reason in the output.
+func SkipIfOverTotalBudget(t *testing.T) {
+	t.Helper()
+	budget := totalBudgetUSD()
+	if budget <= 0 {
+		return
+	}
+	if spent := runLedger.Total(); spent >= budget {
+		t.Skipf("run spend $%.2f has reached EVALS_TOTAL_BUDGET_USD=%.2f; skipping", spent, budget)
+	}
+}
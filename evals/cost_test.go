@@ -0,0 +1,58 @@
+package evals
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestCostLedger(t *testing.T) {
+	var ledger CostLedger
+	ledger.Record("TestEval_Cheap", 0.25)
+	ledger.Record("TestEval_Expensive", 1.50)
+	ledger.Record("TestEval_Mid", 0.50)
+
+	if got := ledger.Total(); got != 2.25 {
+		t.Errorf("Total = %v", got)
+	}
+	breakdown := ledger.Breakdown()
+	if len(breakdown) != 3 || breakdown[0].Test != "TestEval_Expensive" || breakdown[2].Test != "TestEval_Cheap" {
+		t.Errorf("Breakdown = %+v", breakdown)
+	}
+
+	path := filepath.Join(t.TempDir(), "costs.json")
+	if err := ledger.WriteBreakdown(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		TotalUSD float64     `json:"total_usd"`
+		Tests    []CostEntry `json:"tests"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc.TotalUSD != 2.25 || len(doc.Tests) != 3 {
+		t.Errorf("breakdown doc = %+v", doc)
+	}
+}
+
+func TestSkipIfOverTotalBudget(t *testing.T) {
+	t.Setenv("EVALS_TOTAL_BUDGET_USD", "1.00")
+	runLedger.Record(t.Name(), 0.50)
+	defer func() { runLedger.Record(t.Name()+"_refund", -0.50) }()
+
+	// Under budget: must not skip, or the t.Error below never runs.
+	SkipIfOverTotalBudget(t)
+
+	t.Run("over budget skips", func(t *testing.T) {
+		runLedger.Record(t.Name(), 0.60)
+		defer func() { runLedger.Record(t.Name()+"_refund", -0.60) }()
+		SkipIfOverTotalBudget(t)
+		t.Error("should have been skipped")
+	})
+}
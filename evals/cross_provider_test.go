@@ -0,0 +1,94 @@
+package evals
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestCrossProviderEquivalence migrates the same pipeline from GHA and
+// from CircleCI and asserts the two results converge on the same RWX
+// shape — the skills should encode RWX best practices, not mirror
+// source-system quirks.
+func TestCrossProviderEquivalence(t *testing.T) {
+	ghaDir := setupWorkDir(t, "cross-provider/gha")
+	ghaResult := runEval(t, ghaDir,
+		"Migrate the GitHub Actions workflow at .github/workflows/ci.yml to RWX.")
+	assertSkillUsed(t, ghaResult, "migrate-from-gha")
+
+	circleDir := setupWorkDir(t, "cross-provider/circleci")
+	runEval(t, circleDir,
+		"Migrate the CircleCI config at .circleci/config.yml to RWX.")
+
+	for _, workDir := range []string{ghaDir, circleDir} {
+		AssertConfig(t, workDir,
+			ClonesRepo(),
+			InstallsGo(),
+			AnyTaskRunContains("go test"),
+			HasProblemMatcherFreeOutput(),
+		)
+	}
+
+	ghaCfg, err := LoadRWXConfigs(ghaDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	circleCfg, err := LoadRWXConfigs(circleDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	assertConfigsEquivalent(t, ghaCfg, circleCfg)
+}
+
+// assertConfigsEquivalent compares the semantically meaningful surface of
+// two configs: the set of packages called and the set of commands run.
+// Task keys and ordering are allowed to differ; when the key sets do
+// match, the full semantic diff must be empty.
+func assertConfigsEquivalent(t *testing.T, a, b *RWXConfig) {
+	t.Helper()
+	if aCalls, bCalls := callPrefixSet(a), callPrefixSet(b); !equalStringSets(aCalls, bCalls) {
+		t.Errorf("package calls diverge:\n  gha:    %v\n  circle: %v", aCalls, bCalls)
+	}
+	if aCmds, bCmds := runCommandSet(a), runCommandSet(b); !equalStringSets(aCmds, bCmds) {
+		t.Errorf("run commands diverge:\n  gha:    %v\n  circle: %v", aCmds, bCmds)
+	}
+	diff := DiffConfigs(a, b)
+	if len(diff.TasksAdded) == 0 && len(diff.TasksRemoved) == 0 && !diff.Empty() {
+		t.Errorf("configs use the same task keys but drift semantically:\n%s", diff)
+	}
+}
+
+func callPrefixSet(cfg *RWXConfig) []string {
+	seen := map[string]bool{}
+	for _, task := range cfg.Tasks {
+		if task.Call == "" {
+			continue
+		}
+		prefix, _, _ := strings.Cut(task.Call, " ")
+		seen[prefix] = true
+	}
+	return sortedKeys(seen)
+}
+
+func runCommandSet(cfg *RWXConfig) []string {
+	seen := map[string]bool{}
+	for _, task := range cfg.Tasks {
+		for _, line := range strings.Split(task.Run, "\n") {
+			if line = strings.TrimSpace(line); line != "" {
+				seen[line] = true
+			}
+		}
+	}
+	return sortedKeys(seen)
+}
+
+func equalStringSets(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
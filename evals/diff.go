@@ -0,0 +1,178 @@
+package evals
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConfigDiff is a structured, order-insensitive comparison of two
+// configs: which tasks exist only on one side, and which fields changed
+// on tasks present in both.
+type ConfigDiff struct {
+	TasksAdded   []string
+	TasksRemoved []string
+	FieldChanges []FieldChange
+}
+
+// FieldChange is one changed field on a task present in both configs.
+type FieldChange struct {
+	Task  string
+	Field string
+	A     string
+	B     string
+}
+
+// Empty reports whether the two configs are semantically identical.
+func (d *ConfigDiff) Empty() bool {
+	return len(d.TasksAdded) == 0 && len(d.TasksRemoved) == 0 && len(d.FieldChanges) == 0
+}
+
+func (d *ConfigDiff) String() string {
+	if d.Empty() {
+		return "no differences"
+	}
+	var b strings.Builder
+	for _, key := range d.TasksRemoved {
+		fmt.Fprintf(&b, "- task %s\n", key)
+	}
+	for _, key := range d.TasksAdded {
+		fmt.Fprintf(&b, "+ task %s\n", key)
+	}
+	for _, c := range d.FieldChanges {
+		fmt.Fprintf(&b, "~ task %s %s: %q -> %q\n", c.Task, c.Field, c.A, c.B)
+	}
+	return strings.TrimSuffix(b.String(), "\n")
+}
+
+// DiffConfigs compares two configs task-by-task (matched on key),
+// ignoring task order and cosmetic formatting. It powers golden-snapshot
+// evals that tolerate reordering but flag real semantic drift.
+func DiffConfigs(a, b *RWXConfig) *ConfigDiff {
+	diff := &ConfigDiff{}
+	aTasks := tasksByKey(a)
+	bTasks := tasksByKey(b)
+
+	for _, key := range sortedTaskKeys(aTasks) {
+		bt, ok := bTasks[key]
+		if !ok {
+			diff.TasksRemoved = append(diff.TasksRemoved, key)
+			continue
+		}
+		diff.FieldChanges = append(diff.FieldChanges, diffTasks(key, aTasks[key], bt)...)
+	}
+	for _, key := range sortedTaskKeys(bTasks) {
+		if _, ok := aTasks[key]; !ok {
+			diff.TasksAdded = append(diff.TasksAdded, key)
+		}
+	}
+	return diff
+}
+
+func diffTasks(key string, a, b RWXTask) []FieldChange {
+	var changes []FieldChange
+	add := func(field, av, bv string) {
+		if av != bv {
+			changes = append(changes, FieldChange{Task: key, Field: field, A: av, B: bv})
+		}
+	}
+
+	add("call", a.Call, b.Call)
+	add("run", strings.TrimSpace(a.Run), strings.TrimSpace(b.Run))
+	add("use", renderSet(a.Use), renderSet(b.Use))
+	add("filter", renderSet(normalizeGlobs(a.Filter)), renderSet(normalizeGlobs(b.Filter)))
+	add("parallel", renderParallel(a.Parallel), renderParallel(b.Parallel))
+
+	for _, k := range unionKeys(a.With, b.With) {
+		add("with."+k, a.With[k], b.With[k])
+	}
+	for _, k := range unionKeys(a.Env, b.Env) {
+		add("env."+k, a.Env[k], b.Env[k])
+	}
+	add("background-processes", renderBGKeys(a.BackgroundProcesses), renderBGKeys(b.BackgroundProcesses))
+	return changes
+}
+
+func tasksByKey(cfg *RWXConfig) map[string]RWXTask {
+	byKey := make(map[string]RWXTask, len(cfg.Tasks))
+	for _, task := range cfg.Tasks {
+		byKey[task.Key] = task
+	}
+	return byKey
+}
+
+func sortedTaskKeys(tasks map[string]RWXTask) []string {
+	keys := make([]string, 0, len(tasks))
+	for k := range tasks {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func renderSet(values []string) string {
+	sorted := append([]string(nil), values...)
+	sort.Strings(sorted)
+	return strings.Join(sorted, ",")
+}
+
+func normalizeGlobs(globs FlexStrings) []string {
+	out := make([]string, len(globs))
+	for i, g := range globs {
+		out[i] = NormalizeGlob(g)
+	}
+	return out
+}
+
+func renderParallel(p *Parallel) string {
+	if p == nil {
+		return ""
+	}
+	combos := p.ExpandMatrix()
+	if combos == nil {
+		return fmt.Sprintf("count=%d", p.Count)
+	}
+	parts := make([]string, len(combos))
+	for i, combo := range combos {
+		var kv []string
+		for _, k := range sortedKeysOf(combo) {
+			kv = append(kv, k+"="+combo[k])
+		}
+		parts[i] = strings.Join(kv, " ")
+	}
+	return strings.Join(parts, "; ")
+}
+
+func sortedKeysOf(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func unionKeys(a, b StringMap) []string {
+	seen := map[string]bool{}
+	for k := range a {
+		seen[k] = true
+	}
+	for k := range b {
+		seen[k] = true
+	}
+	keys := make([]string, 0, len(seen))
+	for k := range seen {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func renderBGKeys(procs []BGProcess) string {
+	keys := make([]string, len(procs))
+	for i, p := range procs {
+		keys[i] = p.Key
+	}
+	sort.Strings(keys)
+	return strings.Join(keys, ",")
+}
@@ -0,0 +1,65 @@
+package evals
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestDiffConfigsIdentical(t *testing.T) {
+	a := mustParse(t, sampleConfig)
+	b := mustParse(t, sampleConfig)
+	if diff := DiffConfigs(a, b); !diff.Empty() {
+		t.Errorf("identical configs diff:\n%s", diff)
+	}
+}
+
+func TestDiffConfigsIgnoresCosmetics(t *testing.T) {
+	a := mustParse(t, `
+tasks:
+  - key: test
+    use: [code, go]
+    run: "go test ./...  "
+    filter:
+      - ./pkg/**
+  - key: code
+    call: git/clone 2.0.3
+`)
+	b := mustParse(t, `
+tasks:
+  - key: code
+    call: git/clone 2.0.3
+  - key: test
+    use: [go, code]
+    run: go test ./...
+    filter:
+      - pkg/**
+`)
+	if diff := DiffConfigs(a, b); !diff.Empty() {
+		t.Errorf("cosmetic differences flagged:\n%s", diff)
+	}
+}
+
+func TestDiffConfigsFlagsSemanticDrift(t *testing.T) {
+	a := mustParse(t, sampleConfig)
+	b := mustParse(t, sampleConfig)
+	b.Tasks = b.Tasks[:2] // drop the test task
+	b.Tasks[1].With["go-version"] = "1.21"
+	b.Tasks = append(b.Tasks, RWXTask{Key: "lint", Run: "golangci-lint run"})
+
+	diff := DiffConfigs(a, b)
+	if diff.Empty() {
+		t.Fatal("expected differences")
+	}
+	if len(diff.TasksRemoved) != 1 || diff.TasksRemoved[0] != "test" {
+		t.Errorf("TasksRemoved = %v", diff.TasksRemoved)
+	}
+	if len(diff.TasksAdded) != 1 || diff.TasksAdded[0] != "lint" {
+		t.Errorf("TasksAdded = %v", diff.TasksAdded)
+	}
+	if len(diff.FieldChanges) != 1 || diff.FieldChanges[0].Field != "with.go-version" {
+		t.Errorf("FieldChanges = %v", diff.FieldChanges)
+	}
+	if !strings.Contains(diff.String(), "with.go-version") {
+		t.Errorf("String() = %s", diff)
+	}
+}
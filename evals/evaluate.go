@@ -0,0 +1,55 @@
+package evals
+
+// AssertionResult is one assertion's outcome from EvaluateConfig.
+type AssertionResult struct {
+	Name        string   `json:"name"`
+	Description string   `json:"description,omitempty"`
+	Passed      bool     `json:"passed"`
+	Messages    []string `json:"messages,omitempty"`
+}
+
+// Results collects per-assertion outcomes.
+type Results []AssertionResult
+
+// Passed reports whether every assertion passed.
+func (r Results) Passed() bool {
+	for _, result := range r {
+		if !result.Passed {
+			return false
+		}
+	}
+	return true
+}
+
+// Failures returns only the failing results.
+func (r Results) Failures() Results {
+	var out Results
+	for _, result := range r {
+		if !result.Passed {
+			out = append(out, result)
+		}
+	}
+	return out
+}
+
+// EvaluateConfig runs the assertions against the configs generated under
+// workDir and returns structured results instead of reporting through a
+// *testing.T. The same invariants can then power a CLI report or feed a
+// scoring pipeline; AssertConfig remains the subtest-based entry point.
+func EvaluateConfig(workDir string, assertions ...ConfigAssertion) (Results, error) {
+	cfg, err := LoadRWXConfigs(workDir)
+	if err != nil {
+		return nil, err
+	}
+	results := make(Results, 0, len(assertions))
+	for _, a := range assertions {
+		messages, failed := Probe(a, cfg)
+		results = append(results, AssertionResult{
+			Name:        a.Name,
+			Description: a.Description,
+			Passed:      !failed,
+			Messages:    messages,
+		})
+	}
+	return results, nil
+}
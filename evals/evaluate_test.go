@@ -0,0 +1,49 @@
+package evals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEvaluateConfig(t *testing.T) {
+	workDir := t.TempDir()
+	dir := filepath.Join(workDir, ".rwx")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ci.yml"), []byte(sampleConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	results, err := EvaluateConfig(workDir,
+		ClonesRepo(),
+		Describe("Go toolchain is installed", InstallsGo()),
+		UsesPackage("nodejs/install"),
+	)
+	if err != nil {
+		t.Fatalf("EvaluateConfig: %v", err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results", len(results))
+	}
+	if results.Passed() {
+		t.Error("suite with a failing assertion reported Passed")
+	}
+	if results[1].Description != "Go toolchain is installed" {
+		t.Errorf("Description = %q", results[1].Description)
+	}
+	failures := results.Failures()
+	if len(failures) != 1 || failures[0].Name != "uses_package_nodejs_install" {
+		t.Errorf("Failures() = %+v", failures)
+	}
+	if len(failures[0].Messages) == 0 {
+		t.Error("failing result should carry messages")
+	}
+}
+
+func TestEvaluateConfigMissingWorkspace(t *testing.T) {
+	if _, err := EvaluateConfig(t.TempDir(), ClonesRepo()); err == nil {
+		t.Error("expected error for workdir without configs")
+	}
+}
@@ -0,0 +1,53 @@
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// FakeRunner is an AgentRunner for harness unit tests: it returns canned
+// events and writes configurable files into workDir, so the plumbing
+// around agent runs can be exercised without spending API budget.
+type FakeRunner struct {
+	// Events is the canned transcript. When empty, Run synthesizes a
+	// minimal success result.
+	Events []ClaudeEvent
+	// Files maps workspace-relative paths (e.g. ".rwx/ci.yml") to the
+	// contents Run writes into workDir.
+	Files map[string]string
+	// Err, when non-nil, is returned from Run instead of a result.
+	Err error
+
+	// Prompts records the prompt of every Run call, in order.
+	Prompts []string
+}
+
+// Run implements AgentRunner.
+func (r *FakeRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	r.Prompts = append(r.Prompts, prompt)
+	if r.Err != nil {
+		return nil, r.Err
+	}
+	for rel, content := range r.Files {
+		path := filepath.Join(workDir, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			return nil, err
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			return nil, err
+		}
+	}
+	events := r.Events
+	if len(events) == 0 {
+		events = []ClaudeEvent{{Type: "result", Subtype: "success"}}
+	}
+	raw, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+	return &ExecutionResult{Events: events, Raw: raw}, nil
+}
+
+var _ AgentRunner = (*FakeRunner)(nil)
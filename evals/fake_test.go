@@ -0,0 +1,60 @@
+package evals
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFakeRunner(t *testing.T) {
+	runner := &FakeRunner{
+		Events: []ClaudeEvent{
+			{Type: "assistant", Message: &ClaudeMessage{Role: "assistant", Content: []ContentBlock{{Type: "tool_use", Name: "Skill", Input: []byte(`{"command":"rwx"}`)}}}},
+			{Type: "result", Subtype: "success"},
+		},
+		Files: map[string]string{".rwx/ci.yml": sampleConfig},
+	}
+
+	workDir := t.TempDir()
+	result, err := runner.Run(context.Background(), "set up CI", workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := result.SkillsUsed(); len(got) != 1 || got[0] != "rwx" {
+		t.Errorf("SkillsUsed = %v", got)
+	}
+	if len(runner.Prompts) != 1 || runner.Prompts[0] != "set up CI" {
+		t.Errorf("Prompts = %v", runner.Prompts)
+	}
+
+	results, err := EvaluateConfig(workDir, ClonesRepo(), InstallsGo())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !results.Passed() {
+		t.Errorf("written workspace failed assertions: %+v", results.Failures())
+	}
+
+	// Raw must round-trip so cassette recording and transcript saving work.
+	reparsed, err := parseClaudeOutput(result.Raw)
+	if err != nil || len(reparsed) != 2 {
+		t.Errorf("Raw did not round-trip: %v, %v", reparsed, err)
+	}
+}
+
+func TestFakeRunnerDefaultsAndError(t *testing.T) {
+	runner := &FakeRunner{}
+	result, err := runner.Run(context.Background(), "anything", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.ResultEvent() == nil {
+		t.Error("empty FakeRunner should synthesize a result event")
+	}
+
+	boom := errors.New("boom")
+	runner = &FakeRunner{Err: boom}
+	if _, err := runner.Run(context.Background(), "anything", t.TempDir()); !errors.Is(err, boom) {
+		t.Errorf("err = %v, want boom", err)
+	}
+}
@@ -0,0 +1,143 @@
+package evals
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FixtureSynthOptions controls how a real-world workflow from a bug
+// report is turned into a checked-in fixture.
+type FixtureSynthOptions struct {
+	// Name is the fixture directory name under testdata/fixtures/gha.
+	Name string
+	// KeepJobs limits the workflow to the named jobs; empty keeps all.
+	KeepJobs []string
+}
+
+// SynthesizedFixture is a minimized, anonymized workflow fixture plus a
+// scaffolded eval derived from its contents.
+type SynthesizedFixture struct {
+	Name     string
+	Workflow []byte
+	EvalStub string
+}
+
+var anonymizePatterns = []struct {
+	re          *regexp.Regexp
+	replacement string
+}{
+	{regexp.MustCompile(`github\.com[:/][\w.-]+/[\w.-]+`), "github.com/example/app"},
+	{regexp.MustCompile(`https://hooks\.slack\.com/\S+`), "https://hooks.slack.com/REDACTED"},
+	{regexp.MustCompile(`ghp_[A-Za-z0-9]{20,}`), "REDACTED"},
+	{regexp.MustCompile(`gho_[A-Za-z0-9]{20,}`), "REDACTED"},
+	{regexp.MustCompile(`AKIA[0-9A-Z]{16}`), "REDACTED"},
+	{regexp.MustCompile(`[\w.+-]+@[\w-]+\.[\w.]+`), "dev@example.com"},
+}
+
+// SynthesizeFixture anonymizes and trims a user-provided GHA workflow so
+// real failures can become regression evals with minimal manual work.
+func SynthesizeFixture(workflow []byte, opts FixtureSynthOptions) (*SynthesizedFixture, error) {
+	if opts.Name == "" {
+		return nil, fmt.Errorf("fixture name is required")
+	}
+
+	var doc map[string]any
+	if err := yaml.Unmarshal(workflow, &doc); err != nil {
+		return nil, fmt.Errorf("parsing workflow: %w", err)
+	}
+	if len(opts.KeepJobs) > 0 {
+		jobs, _ := doc["jobs"].(map[string]any)
+		if jobs == nil {
+			return nil, fmt.Errorf("workflow has no jobs")
+		}
+		trimmed := map[string]any{}
+		for _, name := range opts.KeepJobs {
+			job, ok := jobs[name]
+			if !ok {
+				return nil, fmt.Errorf("workflow has no job %q", name)
+			}
+			trimmed[name] = job
+		}
+		doc["jobs"] = trimmed
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	for _, p := range anonymizePatterns {
+		out = p.re.ReplaceAll(out, []byte(p.replacement))
+	}
+
+	return &SynthesizedFixture{
+		Name:     opts.Name,
+		Workflow: out,
+		EvalStub: deriveEvalStub(opts.Name, string(out)),
+	}, nil
+}
+
+// deriveEvalStub scaffolds a migration eval with assertions inferred
+// from what the workflow does. It is a starting point, not a finished
+// test.
+func deriveEvalStub(name, workflow string) string {
+	var assertions []string
+	if strings.Contains(workflow, "actions/checkout") {
+		assertions = append(assertions, "ClonesRepo(),")
+	}
+	if strings.Contains(workflow, "actions/setup-go") {
+		assertions = append(assertions, "InstallsGo(),")
+	}
+	if strings.Contains(workflow, "actions/setup-node") {
+		assertions = append(assertions, "InstallsNode(),")
+	}
+	if strings.Contains(workflow, "go test") {
+		assertions = append(assertions, `AnyTaskRunContains("go test"),`)
+	}
+	if strings.Contains(workflow, "npm test") {
+		assertions = append(assertions, `AnyTaskRunContains("npm test"),`)
+	}
+	assertions = append(assertions, "MinTaskCount(2),", "HasProblemMatcherFreeOutput(),")
+
+	var b strings.Builder
+	testName := "TestMigrateFromGHA_" + exportName(name)
+	fmt.Fprintf(&b, "func %s(t *testing.T) {\n", testName)
+	fmt.Fprintf(&b, "\tworkDir := setupWorkDir(t, %q)\n", "gha/"+name)
+	b.WriteString("\tresult := runEval(t, workDir,\n")
+	b.WriteString("\t\t\"Migrate the GitHub Actions workflow at .github/workflows/ci.yml to RWX.\")\n\n")
+	b.WriteString("\tassertSkillUsed(t, result, \"migrate-from-gha\")\n")
+	b.WriteString("\tAssertConfig(t, workDir,\n")
+	for _, a := range assertions {
+		b.WriteString("\t\t" + a + "\n")
+	}
+	b.WriteString("\t)\n")
+	fmt.Fprintf(&b, "\tAssertNoRegression(t, %q, result)\n", "migrate_gha_"+sanitizeName(name))
+	b.WriteString("}\n")
+	return b.String()
+}
+
+func exportName(name string) string {
+	parts := strings.FieldsFunc(name, func(r rune) bool { return r == '-' || r == '_' })
+	for i, p := range parts {
+		parts[i] = strings.ToUpper(p[:1]) + p[1:]
+	}
+	return strings.Join(parts, "")
+}
+
+// WriteFixture writes the synthesized workflow under
+// testdata/fixtures/gha/<name> and returns the fixture directory.
+func (f *SynthesizedFixture) WriteFixture(fixturesGHADir string) (string, error) {
+	dir := filepath.Join(fixturesGHADir, f.Name)
+	workflowDir := filepath.Join(dir, ".github", "workflows")
+	if err := os.MkdirAll(workflowDir, 0o755); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(filepath.Join(workflowDir, "ci.yml"), f.Workflow, 0o644); err != nil {
+		return "", err
+	}
+	return dir, nil
+}
@@ -0,0 +1,120 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// EvalRepeats returns how many times each eval should run, from
+// EVALS_REPEAT. The default of 1 is normal operation; higher values are
+// the flakiness-measurement mode, where per-assertion pass rates across
+// the repeats are written out as a report.
+func EvalRepeats() int {
+	raw := os.Getenv("EVALS_REPEAT")
+	if raw == "" {
+		return 1
+	}
+	n, err := strconv.Atoi(raw)
+	if err != nil || n < 1 {
+		return 1
+	}
+	return n
+}
+
+// RepeatEval runs body once normally, or EvalRepeats() times as repN
+// subtests when repetition is enabled. Wrap an eval's whole body so each
+// repeat gets a fresh workdir and run.
+func RepeatEval(t *testing.T, body func(t *testing.T)) {
+	n := EvalRepeats()
+	if n <= 1 {
+		body(t)
+		return
+	}
+	for i := 1; i <= n; i++ {
+		t.Run(fmt.Sprintf("rep%d", i), body)
+	}
+}
+
+// repeatSegmentRE strips the repN subtest segment, so every repeat of an
+// assertion tallies under one key.
+var repeatSegmentRE = regexp.MustCompile(`/rep\d+`)
+
+type assertionTally struct {
+	runs   int
+	passes int
+}
+
+var flakinessTallies = struct {
+	sync.Mutex
+	byKey map[string]*assertionTally
+}{byKey: map[string]*assertionTally{}}
+
+// recordAssertionOutcome tallies one assertion execution for the
+// flakiness report.
+func recordAssertionOutcome(test, assertion string, passed bool) {
+	key := repeatSegmentRE.ReplaceAllString(test, "") + "\x00" + assertion
+	flakinessTallies.Lock()
+	defer flakinessTallies.Unlock()
+	tally := flakinessTallies.byKey[key]
+	if tally == nil {
+		tally = &assertionTally{}
+		flakinessTallies.byKey[key] = tally
+	}
+	tally.runs++
+	if passed {
+		tally.passes++
+	}
+}
+
+// FlakinessEntry is one assertion's stability across a run's repeats.
+type FlakinessEntry struct {
+	Test      string  `json:"test"`
+	Assertion string  `json:"assertion"`
+	Runs      int     `json:"runs"`
+	Passes    int     `json:"passes"`
+	PassRate  float64 `json:"pass_rate"`
+}
+
+// FlakinessEntries returns the tallied outcomes, least stable first.
+func FlakinessEntries() []FlakinessEntry {
+	flakinessTallies.Lock()
+	defer flakinessTallies.Unlock()
+	entries := make([]FlakinessEntry, 0, len(flakinessTallies.byKey))
+	for key, tally := range flakinessTallies.byKey {
+		test, assertion, _ := strings.Cut(key, "\x00")
+		entries = append(entries, FlakinessEntry{
+			Test:      test,
+			Assertion: assertion,
+			Runs:      tally.runs,
+			Passes:    tally.passes,
+			PassRate:  float64(tally.passes) / float64(tally.runs),
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].PassRate != entries[j].PassRate {
+			return entries[i].PassRate < entries[j].PassRate
+		}
+		if entries[i].Test != entries[j].Test {
+			return entries[i].Test < entries[j].Test
+		}
+		return entries[i].Assertion < entries[j].Assertion
+	})
+	return entries
+}
+
+// WriteFlakinessReport writes the per-assertion pass rates as JSON, the
+// data needed before tuning thresholds or quarantining an invariant.
+func WriteFlakinessReport(path string) error {
+	data, err := json.MarshalIndent(FlakinessEntries(), "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,85 @@
+package evals
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestEvalRepeats(t *testing.T) {
+	t.Setenv("EVALS_REPEAT", "")
+	if got := EvalRepeats(); got != 1 {
+		t.Errorf("default = %d", got)
+	}
+	t.Setenv("EVALS_REPEAT", "5")
+	if got := EvalRepeats(); got != 5 {
+		t.Errorf("env = %d", got)
+	}
+	t.Setenv("EVALS_REPEAT", "bogus")
+	if got := EvalRepeats(); got != 1 {
+		t.Errorf("invalid should fall back: %d", got)
+	}
+}
+
+func TestRepeatEval(t *testing.T) {
+	t.Setenv("EVALS_REPEAT", "3")
+	runs := 0
+	RepeatEval(t, func(t *testing.T) { runs++ })
+	if runs != 3 {
+		t.Errorf("body ran %d times, want 3", runs)
+	}
+
+	t.Setenv("EVALS_REPEAT", "")
+	runs = 0
+	RepeatEval(t, func(t *testing.T) { runs++ })
+	if runs != 1 {
+		t.Errorf("body ran %d times, want 1", runs)
+	}
+}
+
+func TestFlakinessEntries(t *testing.T) {
+	for i := 0; i < 3; i++ {
+		recordAssertionOutcome("TestFlaky_Fake/rep"+string(rune('1'+i)), "installs_go", i != 1)
+	}
+	recordAssertionOutcome("TestFlaky_Fake/rep1", "min_tasks_3", true)
+
+	var flaky, stable *FlakinessEntry
+	for _, entry := range FlakinessEntries() {
+		entry := entry
+		if entry.Test != "TestFlaky_Fake" {
+			continue
+		}
+		switch entry.Assertion {
+		case "installs_go":
+			flaky = &entry
+		case "min_tasks_3":
+			stable = &entry
+		}
+	}
+	if flaky == nil || flaky.Runs != 3 || flaky.Passes != 2 {
+		t.Fatalf("flaky entry = %+v", flaky)
+	}
+	if stable == nil || stable.PassRate != 1 {
+		t.Fatalf("stable entry = %+v", stable)
+	}
+	if flaky.PassRate >= stable.PassRate {
+		t.Errorf("pass rates: flaky %v, stable %v", flaky.PassRate, stable.PassRate)
+	}
+
+	path := filepath.Join(t.TempDir(), "flakiness.json")
+	if err := WriteFlakinessReport(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var entries []FlakinessEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) == 0 {
+		t.Error("report is empty")
+	}
+}
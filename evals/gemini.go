@@ -0,0 +1,110 @@
+package evals
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"sort"
+	"time"
+)
+
+// GeminiRunner runs the gemini CLI in non-interactive mode and adapts
+// its JSON output into an ExecutionResult. Gemini reports a single
+// response document with aggregate stats rather than an event stream, so
+// the adapted transcript is coarser than Claude's: one assistant event,
+// one tool_use block per tool it reports having called, and a result
+// event carrying the token totals.
+type GeminiRunner struct {
+	// Model selects the model via -m; empty uses the CLI default.
+	Model string
+	// Command overrides the executable, for tests. Defaults to "gemini".
+	Command string
+}
+
+// geminiOutput is the JSON document `gemini --output-format json` prints.
+type geminiOutput struct {
+	Response string `json:"response"`
+	Stats    struct {
+		Models map[string]struct {
+			Tokens struct {
+				Prompt     int `json:"prompt"`
+				Candidates int `json:"candidates"`
+			} `json:"tokens"`
+		} `json:"models"`
+		Tools struct {
+			ByName map[string]struct {
+				Count int `json:"count"`
+			} `json:"byName"`
+		} `json:"tools"`
+	} `json:"stats"`
+}
+
+// Run implements AgentRunner.
+func (r *GeminiRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	command := r.Command
+	if command == "" {
+		command = "gemini"
+	}
+	args := []string{"--output-format", "json", "--yolo"}
+	if r.Model != "" {
+		args = append(args, "-m", r.Model)
+	}
+	args = append(args, "-p", prompt)
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = workDir
+
+	start := time.Now()
+	out, err := cmd.Output()
+	elapsed := time.Since(start)
+	if err != nil {
+		if ee, ok := err.(*exec.ExitError); ok {
+			return nil, fmt.Errorf("gemini exited with %v: %s", ee.ExitCode(), ee.Stderr)
+		}
+		return nil, err
+	}
+
+	events, err := translateGeminiOutput(out, elapsed)
+	if err != nil {
+		return nil, fmt.Errorf("parsing gemini output: %w", err)
+	}
+	return &ExecutionResult{Events: events, Raw: out, Duration: elapsed}, nil
+}
+
+func translateGeminiOutput(out []byte, elapsed time.Duration) ([]ClaudeEvent, error) {
+	var doc geminiOutput
+	if err := json.Unmarshal(out, &doc); err != nil {
+		return nil, err
+	}
+
+	var blocks []ContentBlock
+	for _, name := range sortedKeys(doc.Stats.Tools.ByName) {
+		blocks = append(blocks, ContentBlock{Type: "tool_use", Name: name, Input: json.RawMessage("{}")})
+	}
+	if doc.Response != "" {
+		blocks = append(blocks, ContentBlock{Type: "text", Text: doc.Response})
+	}
+
+	var usage ClaudeUsage
+	for _, model := range doc.Stats.Models {
+		usage.InputTokens += model.Tokens.Prompt
+		usage.OutputTokens += model.Tokens.Candidates
+	}
+
+	events := []ClaudeEvent{
+		{Type: "assistant", Message: &ClaudeMessage{Role: "assistant", Content: blocks}},
+		{Type: "result", Subtype: "success", Result: doc.Response, DurationMS: elapsed.Milliseconds(), Usage: &usage},
+	}
+	return events, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var _ AgentRunner = (*GeminiRunner)(nil)
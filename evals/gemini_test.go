@@ -0,0 +1,58 @@
+package evals
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestGeminiRunner(t *testing.T) {
+	fake := filepath.Join(t.TempDir(), "fake-gemini")
+	script := `#!/bin/sh
+cat <<'EOF'
+{
+  "response": "wrote .rwx/ci.yml",
+  "stats": {
+    "models": {
+      "gemini-2.5-pro": {"tokens": {"prompt": 200, "candidates": 50}}
+    },
+    "tools": {
+      "byName": {
+        "write_file": {"count": 2},
+        "run_shell_command": {"count": 1}
+      }
+    }
+  }
+}
+EOF
+`
+	if err := os.WriteFile(fake, []byte(script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	runner := &GeminiRunner{Command: fake}
+	result, err := runner.Run(context.Background(), "migrate this repo", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Events) != 2 {
+		t.Fatalf("got %d events: %+v", len(result.Events), result.Events)
+	}
+	tools := result.ToolsUsed()
+	if len(tools) != 2 || tools[0] != "run_shell_command" || tools[1] != "write_file" {
+		t.Errorf("ToolsUsed = %v", tools)
+	}
+	if got := result.TotalTokens(); got != 250 {
+		t.Errorf("TotalTokens = %d, want 250", got)
+	}
+	if res := result.ResultEvent(); res == nil || res.Result != "wrote .rwx/ci.yml" {
+		t.Errorf("ResultEvent = %+v", res)
+	}
+}
+
+func TestTranslateGeminiOutputRejectsGarbage(t *testing.T) {
+	if _, err := translateGeminiOutput([]byte("not json"), 0); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,204 @@
+package evals
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// GHAMatrix is the strategy.matrix block of one GitHub Actions job,
+// including include/exclude adjustments — the part of GHA that is most
+// frequently mistranslated into RWX parallelism.
+type GHAMatrix struct {
+	Dimensions map[string][]string
+	Include    []map[string]string
+	Exclude    []map[string]string
+}
+
+// ParseGHAWorkflowMatrices extracts the matrix of every job in a GHA
+// workflow, keyed by job name. Jobs without a matrix are omitted.
+func ParseGHAWorkflowMatrices(workflow []byte) (map[string]GHAMatrix, error) {
+	var doc struct {
+		Jobs map[string]struct {
+			Strategy struct {
+				Matrix map[string]any `yaml:"matrix"`
+			} `yaml:"strategy"`
+		} `yaml:"jobs"`
+	}
+	if err := yaml.Unmarshal(workflow, &doc); err != nil {
+		return nil, fmt.Errorf("parsing workflow: %w", err)
+	}
+
+	matrices := map[string]GHAMatrix{}
+	for job, spec := range doc.Jobs {
+		if len(spec.Strategy.Matrix) == 0 {
+			continue
+		}
+		matrix := GHAMatrix{Dimensions: map[string][]string{}}
+		for key, raw := range spec.Strategy.Matrix {
+			switch key {
+			case "include":
+				matrix.Include = toStringMaps(raw)
+			case "exclude":
+				matrix.Exclude = toStringMaps(raw)
+			default:
+				if values, ok := raw.([]any); ok {
+					for _, v := range values {
+						matrix.Dimensions[key] = append(matrix.Dimensions[key], fmt.Sprint(v))
+					}
+				}
+			}
+		}
+		matrices[job] = matrix
+	}
+	return matrices, nil
+}
+
+func toStringMaps(raw any) []map[string]string {
+	items, ok := raw.([]any)
+	if !ok {
+		return nil
+	}
+	var out []map[string]string
+	for _, item := range items {
+		m, ok := item.(map[string]any)
+		if !ok {
+			continue
+		}
+		entry := map[string]string{}
+		for k, v := range m {
+			entry[k] = fmt.Sprint(v)
+		}
+		out = append(out, entry)
+	}
+	return out
+}
+
+// EffectiveCombinations expands the matrix the way GHA does: the
+// cartesian product of the dimensions, minus exclude matches, with
+// include entries either extending matching combinations or appended as
+// new ones.
+func (m GHAMatrix) EffectiveCombinations() []map[string]string {
+	combos := (&Parallel{Matrix: m.Dimensions}).ExpandMatrix()
+
+	var kept []map[string]string
+	for _, combo := range combos {
+		if !matchesAny(combo, m.Exclude) {
+			kept = append(kept, combo)
+		}
+	}
+
+	for _, inc := range m.Include {
+		extended := false
+		for _, combo := range kept {
+			if includeMatches(combo, inc, m.Dimensions) {
+				for k, v := range inc {
+					if _, isDim := m.Dimensions[k]; !isDim {
+						combo[k] = v
+					}
+				}
+				extended = true
+			}
+		}
+		if !extended {
+			kept = append(kept, inc)
+		}
+	}
+	return kept
+}
+
+// matchesAny reports whether an exclude entry is a subset of the combo.
+func matchesAny(combo map[string]string, excludes []map[string]string) bool {
+	for _, ex := range excludes {
+		matches := true
+		for k, v := range ex {
+			if combo[k] != v {
+				matches = false
+				break
+			}
+		}
+		if matches {
+			return true
+		}
+	}
+	return false
+}
+
+// includeMatches reports whether an include entry's dimension keys all
+// match the combo, meaning it extends rather than adds.
+func includeMatches(combo, inc map[string]string, dims map[string][]string) bool {
+	matched := false
+	for k, v := range inc {
+		if _, isDim := dims[k]; !isDim {
+			continue
+		}
+		if combo[k] != v {
+			return false
+		}
+		matched = true
+	}
+	return matched
+}
+
+// CheckMatrixTranslation verifies that every effective combination of
+// every job matrix in the source workflow is represented in the RWX
+// config — either by a task whose parallel expansion covers it, or by a
+// dedicated task. It returns a description of each missing combination.
+func CheckMatrixTranslation(workflow []byte, cfg *RWXConfig) ([]string, error) {
+	matrices, err := ParseGHAWorkflowMatrices(workflow)
+	if err != nil {
+		return nil, err
+	}
+
+	var rwxCombos []map[string]string
+	for _, task := range cfg.Tasks {
+		rwxCombos = append(rwxCombos, task.Parallel.ExpandMatrix()...)
+	}
+
+	var missing []string
+	for _, job := range sortedMatrixJobs(matrices) {
+		for _, combo := range matrices[job].EffectiveCombinations() {
+			if !comboRepresented(combo, rwxCombos) {
+				missing = append(missing, fmt.Sprintf("%s: %s", job, renderCombo(combo)))
+			}
+		}
+	}
+	return missing, nil
+}
+
+// comboRepresented reports whether some RWX parallel combination covers
+// every key/value of the source combination.
+func comboRepresented(combo map[string]string, rwxCombos []map[string]string) bool {
+	for _, rc := range rwxCombos {
+		covered := true
+		for k, v := range combo {
+			if rc[k] != v {
+				covered = false
+				break
+			}
+		}
+		if covered {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedMatrixJobs(matrices map[string]GHAMatrix) []string {
+	jobs := make([]string, 0, len(matrices))
+	for job := range matrices {
+		jobs = append(jobs, job)
+	}
+	sort.Strings(jobs)
+	return jobs
+}
+
+func renderCombo(combo map[string]string) string {
+	var parts []string
+	for _, k := range sortedKeysOf(combo) {
+		parts = append(parts, k+"="+combo[k])
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,83 @@
+package evals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func matrixFixtureWorkflow(t *testing.T) []byte {
+	t.Helper()
+	path := filepath.Join(evalsDir(), "testdata", "fixtures", "gha",
+		"matrix-include-exclude", ".github", "workflows", "ci.yml")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestGHAEffectiveCombinations(t *testing.T) {
+	matrices, err := ParseGHAWorkflowMatrices(matrixFixtureWorkflow(t))
+	if err != nil {
+		t.Fatal(err)
+	}
+	matrix, ok := matrices["test"]
+	if !ok {
+		t.Fatalf("matrices = %v", matrices)
+	}
+
+	combos := matrix.EffectiveCombinations()
+	// 2x2 cartesian, minus the 1.22/macos exclude, plus the new 1.21 row.
+	if len(combos) != 4 {
+		t.Fatalf("got %d combos: %v", len(combos), combos)
+	}
+	if comboRepresented(map[string]string{"go-version": "1.22", "os": "macos-latest"}, combos) {
+		t.Error("excluded combo survived")
+	}
+	if !comboRepresented(map[string]string{"go-version": "1.21", "os": "ubuntu-latest"}, combos) {
+		t.Error("include-only combo missing")
+	}
+	// The matching include extends the existing combo with coverage.
+	if !comboRepresented(map[string]string{"go-version": "1.26", "os": "ubuntu-latest", "coverage": "true"}, combos) {
+		t.Errorf("include did not extend matching combo: %v", combos)
+	}
+}
+
+func TestCheckMatrixTranslation(t *testing.T) {
+	workflow := matrixFixtureWorkflow(t)
+
+	complete := mustParse(t, `
+tasks:
+  - key: test
+    run: go test ./...
+    parallel:
+      matrix:
+        go-version: ["1.21", "1.22", "1.26"]
+        os: [ubuntu-latest, macos-latest]
+        coverage: ["true", "false"]
+`)
+	missing, err := CheckMatrixTranslation(workflow, complete)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) != 0 {
+		t.Errorf("missing = %v", missing)
+	}
+
+	partial := mustParse(t, `
+tasks:
+  - key: test
+    run: go test ./...
+    parallel:
+      key: go-version
+      values: ["1.22", "1.26"]
+`)
+	missing, err = CheckMatrixTranslation(workflow, partial)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(missing) == 0 {
+		t.Error("expected missing combinations for os-less translation")
+	}
+}
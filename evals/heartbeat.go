@@ -0,0 +1,89 @@
+package evals
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// heartbeatInterval is how often a running eval logs liveness.
+const heartbeatInterval = 30 * time.Second
+
+// Heartbeat periodically logs that a long eval is still alive, so CI
+// logs show a stuck run well before the timeout fires. Feed it events
+// via Observe (e.g. as an SDKRunner OnEvent) and the beats include the
+// last tool used and the cost so far.
+type Heartbeat struct {
+	t     TB
+	start time.Time
+	stop  chan struct{}
+	done  chan struct{}
+
+	mu       sync.Mutex
+	lastTool string
+	cost     float64
+}
+
+// StartHeartbeat begins beating on its own goroutine. Call Stop when the
+// run finishes.
+func StartHeartbeat(t TB, interval time.Duration) *Heartbeat {
+	h := &Heartbeat{
+		t:     t,
+		start: time.Now(),
+		stop:  make(chan struct{}),
+		done:  make(chan struct{}),
+	}
+	go func() {
+		defer close(h.done)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-h.stop:
+				return
+			case <-ticker.C:
+				h.beat()
+			}
+		}
+	}()
+	return h
+}
+
+// Observe updates the heartbeat's view of the run from a streamed event.
+func (h *Heartbeat) Observe(ev ClaudeEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if ev.TotalCostUSD > 0 {
+		h.cost = ev.TotalCostUSD
+	}
+	if ev.Message == nil {
+		return
+	}
+	for _, block := range ev.Message.Content {
+		if block.Type == "tool_use" {
+			h.lastTool = block.Name
+		}
+	}
+}
+
+func (h *Heartbeat) beat() {
+	h.mu.Lock()
+	lastTool, cost := h.lastTool, h.cost
+	h.mu.Unlock()
+
+	msg := fmt.Sprintf("still running after %s", time.Since(h.start).Round(time.Second))
+	if lastTool != "" {
+		msg += fmt.Sprintf(" (last tool: %s)", lastTool)
+	}
+	if cost > 0 {
+		msg += fmt.Sprintf(" ($%.4f so far)", cost)
+	}
+	h.t.Logf("%s", msg)
+}
+
+// Stop ends the heartbeat and waits for the logging goroutine to exit,
+// so no beat lands after the test finishes.
+func (h *Heartbeat) Stop() {
+	close(h.stop)
+	<-h.done
+}
@@ -0,0 +1,44 @@
+package evals
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestHeartbeat(t *testing.T) {
+	probe := &probeTB{}
+	h := StartHeartbeat(probe, 5*time.Millisecond)
+	h.Observe(ClaudeEvent{
+		Type:         "assistant",
+		TotalCostUSD: 0.25,
+		Message: &ClaudeMessage{Content: []ContentBlock{
+			{Type: "tool_use", Name: "Bash"},
+		}},
+	})
+	time.Sleep(30 * time.Millisecond)
+	h.Stop()
+
+	if len(probe.messages) == 0 {
+		t.Fatal("no heartbeats logged")
+	}
+	last := probe.messages[len(probe.messages)-1]
+	if !strings.Contains(last, "still running after") {
+		t.Errorf("beat = %q", last)
+	}
+	if !strings.Contains(last, "last tool: Bash") || !strings.Contains(last, "$0.2500") {
+		t.Errorf("beat missing observed state: %q", last)
+	}
+	if probe.failed {
+		t.Error("heartbeat should never fail the test")
+	}
+}
+
+func TestHeartbeatStopsCleanly(t *testing.T) {
+	probe := &probeTB{}
+	h := StartHeartbeat(probe, time.Hour)
+	h.Stop()
+	if len(probe.messages) != 0 {
+		t.Errorf("unexpected beats: %v", probe.messages)
+	}
+}
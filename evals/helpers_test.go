@@ -0,0 +1,268 @@
+package evals
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+	"time"
+)
+
+const evalTimeout = 15 * time.Minute
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	profile := filepath.Join(RunArtifactsDir(), "assertion_profile.json")
+	if err := WriteAssertionProfile(profile); err != nil {
+		fmt.Fprintf(os.Stderr, "writing assertion profile: %v\n", err)
+	}
+	if TotalCostUSD() > 0 {
+		costs := filepath.Join(RunArtifactsDir(), "cost_breakdown.json")
+		if err := runLedger.WriteBreakdown(costs); err != nil {
+			fmt.Fprintf(os.Stderr, "writing cost breakdown: %v\n", err)
+		}
+	}
+	if EvalRepeats() > 1 {
+		report := filepath.Join(RunArtifactsDir(), "flakiness.json")
+		if err := WriteFlakinessReport(report); err != nil {
+			fmt.Fprintf(os.Stderr, "writing flakiness report: %v\n", err)
+		}
+	}
+	if path := OTLPFile(); path != "" {
+		if err := WriteOTLPTrace(path); err != nil {
+			fmt.Fprintf(os.Stderr, "writing OTLP trace: %v\n", err)
+		}
+	}
+	os.Exit(code)
+}
+
+func evalsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Dir(file)
+}
+
+// setupWorkDir copies a fixture project from testdata/fixtures into a
+// fresh temp dir for the agent to work in.
+func setupWorkDir(t *testing.T, fixture string) string {
+	t.Helper()
+	src := filepath.Join(evalsDir(), "testdata", "fixtures", fixture)
+	if _, err := os.Stat(src); err != nil {
+		t.Fatalf("fixture %s: %v", fixture, err)
+	}
+	dst := t.TempDir()
+	if err := copyTree(src, dst); err != nil {
+		t.Fatalf("copying fixture %s: %v", fixture, err)
+	}
+	return dst
+}
+
+// setupTemplateWorkDir renders a templated fixture skeleton from
+// testdata/fixtures into a fresh temp dir, substituting params into
+// *.tmpl files.
+func setupTemplateWorkDir(t *testing.T, fixture string, params FixtureParams) string {
+	t.Helper()
+	src := filepath.Join(evalsDir(), "testdata", "fixtures", fixture)
+	if _, err := os.Stat(src); err != nil {
+		t.Fatalf("fixture %s: %v", fixture, err)
+	}
+	dst := t.TempDir()
+	if err := RenderFixture(src, dst, params); err != nil {
+		t.Fatalf("rendering fixture %s: %v", fixture, err)
+	}
+	return dst
+}
+
+// setupWorkDirWith is setupWorkDir plus project-level Claude
+// configuration (CLAUDE.md, settings) written in before the run.
+func setupWorkDirWith(t *testing.T, fixture string, cfg ProjectConfig) string {
+	t.Helper()
+	workDir := setupWorkDir(t, fixture)
+	if err := cfg.Apply(workDir); err != nil {
+		t.Fatalf("applying project config: %v", err)
+	}
+	return workDir
+}
+
+func copyTree(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if info.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		in, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer in.Close()
+		out, err := os.OpenFile(target, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, info.Mode())
+		if err != nil {
+			return err
+		}
+		defer out.Close()
+		_, err = io.Copy(out, in)
+		return err
+	})
+}
+
+// runEval runs the claude CLI for an eval, skipping unless evals are
+// enabled and the CLI is available. These runs cost real money, so they
+// are opt-in via EVALS_RUN=1.
+func runEval(t *testing.T, workDir, prompt string) *ExecutionResult {
+	t.Helper()
+	if os.Getenv("EVALS_RUN") == "" {
+		t.Skip("set EVALS_RUN=1 to run agent evals")
+	}
+	if _, err := exec.LookPath("claude"); err != nil {
+		t.Skip("claude CLI not on PATH")
+	}
+	shard, err := ShardFromEnv()
+	if err != nil {
+		t.Fatalf("shard config: %v", err)
+	}
+	if !shard.Owns(t.Name()) {
+		t.Skipf("eval belongs to another shard (index %d of %d)", shard.Index, shard.Total)
+	}
+	SkipIfUnaffected(t)
+	ParallelEval(t)
+
+	ctx, cancel := context.WithTimeout(context.Background(), evalTimeout)
+	defer cancel()
+
+	span := StartSpan("claude.run", map[string]string{
+		"test":  t.Name(),
+		"model": RunOptions{}.model(),
+	})
+	heartbeat := StartHeartbeat(t, heartbeatInterval)
+	result, err := RunClaude(ctx, prompt, workDir)
+	heartbeat.Stop()
+	span.End(err)
+	if err != nil {
+		if result != nil {
+			// Timed-out runs still carry a partial transcript; keep the
+			// diagnostics instead of discarding them with the failure.
+			saveClaudeOutput(t, result)
+			t.Logf("partial run: %d events, %d tokens, tools used: %v",
+				len(result.Events), result.TotalTokens(), result.ToolsUsed())
+		}
+		t.Fatalf("running claude: %v", err)
+	}
+	RecordCost(t.Name(), result)
+	saveClaudeOutput(t, result)
+	saveGeneratedConfigs(t, workDir)
+	for _, denial := range result.PermissionDenials() {
+		t.Logf("permission denied for tool %q: %s", denial.ToolName, denial.ToolInput)
+	}
+	return result
+}
+
+// runNoSkillEval runs an eval whose prompt should NOT trigger the rwx
+// skills: it fails when any skill fires or when .rwx files appear in the
+// workspace. Overeager triggering on unrelated requests is a regression
+// mode of its own, measured separately from skill quality.
+func runNoSkillEval(t *testing.T, workDir, prompt string) *ExecutionResult {
+	t.Helper()
+	result := runEval(t, workDir, prompt)
+	if used := result.SkillsUsed(); len(used) > 0 {
+		t.Errorf("prompt should not trigger any skill, but invoked %v", used)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, ".rwx")); err == nil {
+		t.Errorf("prompt should not produce RWX configs, but .rwx was created")
+	}
+	return result
+}
+
+// saveGeneratedConfigs snapshots the generated .rwx configs next to the
+// transcript so saved bundles can be triaged and re-asserted offline.
+func saveGeneratedConfigs(t *testing.T, workDir string) {
+	t.Helper()
+	src := filepath.Join(workDir, ".rwx")
+	if _, err := os.Stat(src); err != nil {
+		return
+	}
+	dst := filepath.Join(RunArtifactsDir(), EvalIDFor(t.Name()).ArtifactName()+"_workdir", ".rwx")
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		t.Logf("snapshotting configs: %v", err)
+		return
+	}
+	if err := copyTree(src, dst); err != nil {
+		t.Logf("snapshotting configs: %v", err)
+	}
+	if corpusDir := os.Getenv("EVALS_CORPUS_DIR"); corpusDir != "" {
+		cfg, err := LoadRWXConfigs(workDir)
+		if err != nil {
+			t.Logf("corpus save: %v", err)
+			return
+		}
+		if _, err := OpenCorpus(corpusDir).Save(t.Name(), cfg); err != nil {
+			t.Logf("corpus save: %v", err)
+		}
+	}
+}
+
+// saveClaudeOutput persists the raw transcript for post-run triage. The
+// artifacts dir is overridable so CI can collect it.
+func saveClaudeOutput(t *testing.T, result *ExecutionResult) {
+	t.Helper()
+	id := EvalIDFor(t.Name())
+	dir := RunArtifactsDir()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Logf("[%s] creating artifacts dir: %v", id, err)
+		return
+	}
+	path := filepath.Join(dir, id.ArtifactName()+".json")
+	if err := os.WriteFile(path, result.Raw, 0o644); err != nil {
+		t.Logf("[%s] saving claude output: %v", id, err)
+		return
+	}
+	t.Logf("[%s] saved transcript to %s", id, path)
+}
+
+// assertSkillRegistered fails fast when the named skill never made it
+// into the harness at startup — almost always broken plugin-dir wiring —
+// instead of letting the run fail later on assertSkillUsed.
+func assertSkillRegistered(t *testing.T, result *ExecutionResult, skill string) {
+	t.Helper()
+	for _, registered := range result.RegisteredSkills() {
+		if registered == skill || registered == "/"+skill || strings.HasSuffix(registered, ":"+skill) {
+			return
+		}
+	}
+	t.Errorf("skill %q was not registered at startup (registered: %v); check the plugin dir wiring",
+		skill, result.RegisteredSkills())
+}
+
+// assertSkillNotUsed fails the test when the transcript shows the named
+// skill fired, for evals whose prompt should not have triggered it.
+func assertSkillNotUsed(t *testing.T, result *ExecutionResult, skill string) {
+	t.Helper()
+	for _, used := range result.SkillsUsed() {
+		if used == skill || used == "/"+skill {
+			t.Errorf("skill %q was invoked but the prompt should not trigger it", skill)
+		}
+	}
+}
+
+// assertSkillUsed fails the test when the transcript shows no invocation
+// of the named skill.
+func assertSkillUsed(t *testing.T, result *ExecutionResult, skill string) {
+	t.Helper()
+	for _, used := range result.SkillsUsed() {
+		if used == skill || used == "/"+skill {
+			return
+		}
+	}
+	t.Errorf("skill %q was not used (skills used: %v, tools used: %v)",
+		skill, result.SkillsUsed(), result.ToolsUsed())
+}
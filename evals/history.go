@@ -0,0 +1,143 @@
+package evals
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// historyPath is where accepted runs for an eval accumulate, next to the
+// baseline file itself. One JSON record per line, oldest first.
+func historyPath(eval, model string) string {
+	name := sanitizeName(eval)
+	if model != "" {
+		name += "__" + sanitizeName(model)
+	}
+	return filepath.Join(BaselineDir(), name+".history.jsonl")
+}
+
+// AppendBaselineHistory records an accepted run at the end of the eval's
+// history file. Unlike the baseline file, history is never overwritten,
+// so slow drift across many accepted baselines stays observable.
+func AppendBaselineHistory(b *Baseline) error {
+	if err := os.MkdirAll(BaselineDir(), 0o755); err != nil {
+		return err
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	f, err := os.OpenFile(historyPath(b.Eval, b.Model), os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		return err
+	}
+	return f.Close()
+}
+
+// LoadBaselineHistory reads an eval's accepted runs, oldest first. A
+// missing history file is an empty history, not an error.
+func LoadBaselineHistory(eval, model string) ([]Baseline, error) {
+	f, err := os.Open(historyPath(eval, model))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	var history []Baseline
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var b Baseline
+		if err := json.Unmarshal([]byte(line), &b); err != nil {
+			return nil, fmt.Errorf("parsing history for %s: %w", eval, err)
+		}
+		history = append(history, b)
+	}
+	return history, scanner.Err()
+}
+
+var (
+	gitSHAOnce  sync.Once
+	gitSHAValue string
+)
+
+// gitSHA returns the repo's current HEAD commit, or "" when git is
+// unavailable. History entries carry it so drift can be walked back to
+// the skill change that introduced it.
+func gitSHA() string {
+	gitSHAOnce.Do(func() {
+		cmd := exec.Command("git", "rev-parse", "HEAD")
+		cmd.Dir = filepath.Join(evalsPackageDir(), "..")
+		if out, err := cmd.Output(); err == nil {
+			gitSHAValue = strings.TrimSpace(string(out))
+		}
+	})
+	return gitSHAValue
+}
+
+// trendMinSamples is the smallest history a drift check will read
+// anything into; below it the "trend" is just run-to-run noise.
+const trendMinSamples = 6
+
+// TrendDrift compares the median of the oldest third of values against
+// the median of the newest third and returns the percentage change.
+// It returns 0 when there are fewer than trendMinSamples values or the
+// old median is zero.
+func TrendDrift(values []float64) float64 {
+	if len(values) < trendMinSamples {
+		return 0
+	}
+	third := len(values) / 3
+	oldMedian := medianOf(values[:third])
+	newMedian := medianOf(values[len(values)-third:])
+	if oldMedian <= 0 {
+		return 0
+	}
+	return (newMedian - oldMedian) / oldMedian * 100
+}
+
+// checkTrends flags slow drift across accepted baselines: each per-run
+// threshold tolerates a 20-50% bump, so a metric can ratchet up a little
+// with every accepted baseline and never trip one. The drift thresholds
+// are double the per-run ones, measured from the start of the history
+// window to now.
+func checkTrends(t TB, history []Baseline, current *Baseline) {
+	t.Helper()
+	window := make([]Baseline, 0, len(history)+1)
+	window = append(window, history...)
+	window = append(window, *current)
+	metrics := []struct {
+		name        string
+		value       func(*Baseline) float64
+		maxDriftPct float64
+	}{
+		{"total_tokens", func(b *Baseline) float64 { return float64(b.TotalTokens) }, 40},
+		{"duration_ms", func(b *Baseline) float64 { return float64(b.DurationMS) }, 100},
+		{"num_turns", func(b *Baseline) float64 { return float64(b.NumTurns) }, 50},
+		{"total_cost_usd", func(b *Baseline) float64 { return b.CostUSD }, 50},
+	}
+	for _, metric := range metrics {
+		values := make([]float64, len(window))
+		for i := range window {
+			values[i] = metric.value(&window[i])
+		}
+		if drift := TrendDrift(values); drift > metric.maxDriftPct {
+			t.Errorf("%s has drifted %+.1f%% across the last %d accepted runs (threshold %.0f%%); no single run tripped its threshold, but the trend did",
+				metric.name, drift, len(window), metric.maxDriftPct)
+		}
+	}
+}
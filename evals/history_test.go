@@ -0,0 +1,69 @@
+package evals
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBaselineHistory(t *testing.T) {
+	t.Setenv("EVALS_BASELINES_DIR", t.TempDir())
+
+	for _, tokens := range []int{100, 110, 120} {
+		if err := SaveBaseline(&Baseline{Eval: "e", Model: "sonnet", TotalTokens: tokens}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	history, err := LoadBaselineHistory("e", "sonnet")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 3 || history[0].TotalTokens != 100 || history[2].TotalTokens != 120 {
+		t.Fatalf("history = %+v", history)
+	}
+
+	missing, err := LoadBaselineHistory("other", "sonnet")
+	if err != nil || missing != nil {
+		t.Errorf("missing history should be empty, got %v, %v", missing, err)
+	}
+}
+
+func TestTrendDrift(t *testing.T) {
+	if got := TrendDrift([]float64{100, 100, 100}); got != 0 {
+		t.Errorf("short history drift = %v", got)
+	}
+	if got := TrendDrift([]float64{100, 100, 100, 100, 100, 100}); got != 0 {
+		t.Errorf("flat drift = %v", got)
+	}
+	got := TrendDrift([]float64{100, 100, 110, 120, 150, 150})
+	if got != 50 {
+		t.Errorf("drift = %v, want 50", got)
+	}
+	if got := TrendDrift([]float64{0, 0, 100, 100, 100, 100}); got != 0 {
+		t.Errorf("zero old median drift = %v", got)
+	}
+}
+
+func TestCheckTrends(t *testing.T) {
+	// Each step stays well under the 20% per-run token threshold, but the
+	// window as a whole drifts past the 40% trend threshold.
+	var history []Baseline
+	for _, tokens := range []int{1000, 1100, 1250, 1400, 1550} {
+		history = append(history, Baseline{Eval: "e", TotalTokens: tokens})
+	}
+
+	probe := &probeTB{}
+	checkTrends(probe, history, &Baseline{Eval: "e", TotalTokens: 1700})
+	if !probe.failed {
+		t.Fatal("drifting history should fail")
+	}
+	if !strings.Contains(strings.Join(probe.messages, "\n"), "total_tokens has drifted") {
+		t.Errorf("messages = %v", probe.messages)
+	}
+
+	probe = &probeTB{}
+	checkTrends(probe, history[:2], &Baseline{Eval: "e", TotalTokens: 1700})
+	if probe.failed {
+		t.Errorf("short history should not trip the trend check: %v", probe.messages)
+	}
+}
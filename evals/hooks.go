@@ -0,0 +1,97 @@
+package evals
+
+import "context"
+
+// RunHook observes an agent run at its seams. Cross-cutting concerns —
+// cost accounting, telemetry, artifact saving — implement this once and
+// compose via HookedRunner instead of being hardwired into RunClaude and
+// the test helpers.
+type RunHook interface {
+	// BeforeRun is called before the agent starts.
+	BeforeRun(prompt, workDir string)
+	// OnEvent is called for every transcript event, in order, once the
+	// run has produced them. Buffered runners deliver the whole
+	// transcript after the fact; streaming runners may deliver live.
+	OnEvent(ev ClaudeEvent)
+	// AfterRun is called with the outcome, including partial results
+	// from runs that were cut short.
+	AfterRun(result *ExecutionResult, err error)
+}
+
+// BaseHook is a no-op RunHook for embedding, so hooks implement only the
+// seams they care about.
+type BaseHook struct{}
+
+func (BaseHook) BeforeRun(prompt, workDir string)            {}
+func (BaseHook) OnEvent(ev ClaudeEvent)                      {}
+func (BaseHook) AfterRun(result *ExecutionResult, err error) {}
+
+// HookedRunner wraps an AgentRunner with hooks. BeforeRun fires in hook
+// order, AfterRun in reverse order (middleware style), and OnEvent in
+// hook order per event.
+type HookedRunner struct {
+	Runner AgentRunner
+	Hooks  []RunHook
+}
+
+// Run implements AgentRunner.
+func (r *HookedRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	for _, hook := range r.Hooks {
+		hook.BeforeRun(prompt, workDir)
+	}
+	result, err := r.Runner.Run(ctx, prompt, workDir)
+	if result != nil {
+		for _, ev := range result.Events {
+			for _, hook := range r.Hooks {
+				hook.OnEvent(ev)
+			}
+		}
+	}
+	for i := len(r.Hooks) - 1; i >= 0; i-- {
+		r.Hooks[i].AfterRun(result, err)
+	}
+	return result, err
+}
+
+// CostHook feeds each run's reported cost into the run-wide ledger.
+type CostHook struct {
+	BaseHook
+	// Test is the ledger entry name, usually t.Name().
+	Test string
+}
+
+// AfterRun implements RunHook.
+func (h *CostHook) AfterRun(result *ExecutionResult, err error) {
+	if result != nil {
+		RecordCost(h.Test, result)
+	}
+}
+
+// SpanHook records one telemetry span per run (see otel.go).
+type SpanHook struct {
+	BaseHook
+	// Name is the span name; defaults to "agent.run".
+	Name string
+	// Attrs are attached to every span the hook records.
+	Attrs map[string]string
+
+	span *Span
+}
+
+// BeforeRun implements RunHook.
+func (h *SpanHook) BeforeRun(prompt, workDir string) {
+	name := h.Name
+	if name == "" {
+		name = "agent.run"
+	}
+	h.span = StartSpan(name, h.Attrs)
+}
+
+// AfterRun implements RunHook.
+func (h *SpanHook) AfterRun(result *ExecutionResult, err error) {
+	if h.span != nil {
+		h.span.End(err)
+	}
+}
+
+var _ AgentRunner = (*HookedRunner)(nil)
@@ -0,0 +1,75 @@
+package evals
+
+import (
+	"context"
+	"fmt"
+	"testing"
+)
+
+// traceHook records the order hooks fire in.
+type traceHook struct {
+	BaseHook
+	name string
+	log  *[]string
+}
+
+func (h *traceHook) BeforeRun(prompt, workDir string) {
+	*h.log = append(*h.log, h.name+".before")
+}
+
+func (h *traceHook) OnEvent(ev ClaudeEvent) {
+	*h.log = append(*h.log, fmt.Sprintf("%s.event(%s)", h.name, ev.Type))
+}
+
+func (h *traceHook) AfterRun(result *ExecutionResult, err error) {
+	*h.log = append(*h.log, h.name+".after")
+}
+
+func TestHookedRunner(t *testing.T) {
+	var log []string
+	runner := &HookedRunner{
+		Runner: &FakeRunner{Events: []ClaudeEvent{
+			{Type: "assistant"},
+			{Type: "result", Subtype: "success"},
+		}},
+		Hooks: []RunHook{
+			&traceHook{name: "outer", log: &log},
+			&traceHook{name: "inner", log: &log},
+		},
+	}
+
+	if _, err := runner.Run(context.Background(), "migrate", t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"outer.before", "inner.before",
+		"outer.event(assistant)", "inner.event(assistant)",
+		"outer.event(result)", "inner.event(result)",
+		"inner.after", "outer.after",
+	}
+	if len(log) != len(want) {
+		t.Fatalf("log = %v", log)
+	}
+	for i := range want {
+		if log[i] != want[i] {
+			t.Errorf("log[%d] = %q, want %q", i, log[i], want[i])
+		}
+	}
+}
+
+func TestCostHook(t *testing.T) {
+	before := runLedger.Total()
+	runner := &HookedRunner{
+		Runner: &FakeRunner{Events: []ClaudeEvent{
+			{Type: "result", Subtype: "success", TotalCostUSD: 0.25},
+		}},
+		Hooks: []RunHook{&CostHook{Test: "TestCostHook_Fake"}},
+	}
+	if _, err := runner.Run(context.Background(), "migrate", t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if got := runLedger.Total() - before; got != 0.25 {
+		t.Errorf("ledger delta = %v", got)
+	}
+}
@@ -0,0 +1,91 @@
+package evals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func init() {
+	for _, test := range []string{
+		"TestMigrateFromGHA_GoMatrix",
+		"TestMigrateFromGHA_Monorepo",
+		"TestMigrateFromGHA_MatrixIncludeExclude",
+	} {
+		RegisterEvalDeps(test,
+			"plugins/rwx/skills/migrate-from-gha",
+			"evals/testdata/fixtures/gha",
+		)
+	}
+}
+
+func TestMigrateFromGHA_GoMatrix(t *testing.T) {
+	RepeatEval(t, func(t *testing.T) {
+		workDir := setupWorkDir(t, "gha/go-matrix")
+		result := runEval(t, workDir,
+			"Migrate the GitHub Actions workflow at .github/workflows/ci.yml to RWX.")
+
+		assertSkillUsed(t, result, "migrate-from-gha")
+		AssertConfig(t, workDir,
+			ClonesRepo(),
+			InstallsGo(),
+			MinTaskCount(3),
+			AnyTaskRunContains("go test"),
+			HasParallelValues("go-version", "1.22", "1.26"),
+			HasProblemMatcherFreeOutput(),
+		)
+		AssertNoRegression(t, "migrate_gha_go_matrix", result)
+	})
+}
+
+func TestMigrateFromGHA_Monorepo(t *testing.T) {
+	RepeatEval(t, func(t *testing.T) {
+		workDir := setupWorkDir(t, "gha/monorepo")
+		result := runEval(t, workDir,
+			"Migrate the GitHub Actions workflow at .github/workflows/ci.yml to RWX.")
+
+		assertSkillUsed(t, result, "migrate-from-gha")
+		AssertConfig(t, workDir,
+			ClonesRepo(),
+			InstallsGo(),
+			InstallsNode(),
+			MinTaskCount(4),
+			AnyTaskRunContains("go test"),
+			AllTasksHaveFilters(),
+			HasProblemMatcherFreeOutput(),
+		)
+		AssertNoRegression(t, "migrate_gha_monorepo", result)
+	})
+}
+
+func TestMigrateFromGHA_MatrixIncludeExclude(t *testing.T) {
+	RepeatEval(t, func(t *testing.T) {
+		workDir := setupWorkDir(t, "gha/matrix-include-exclude")
+		result := runEval(t, workDir,
+			"Migrate the GitHub Actions workflow at .github/workflows/ci.yml to RWX.")
+
+		assertSkillUsed(t, result, "migrate-from-gha")
+		AssertConfig(t, workDir,
+			ClonesRepo(),
+			InstallsGo(),
+			AnyTaskRunContains("go test"),
+		)
+
+		workflow, err := os.ReadFile(filepath.Join(workDir, ".github", "workflows", "ci.yml"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		cfg, err := LoadRWXConfigs(workDir)
+		if err != nil {
+			t.Fatal(err)
+		}
+		missing, err := CheckMatrixTranslation(workflow, cfg)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, combo := range missing {
+			t.Errorf("matrix combination not represented: %s", combo)
+		}
+		AssertNoRegression(t, "migrate_gha_matrix_include_exclude", result)
+	})
+}
@@ -0,0 +1,16 @@
+package evals
+
+import "testing"
+
+// Negative evals: prompts with nothing RWX about them must leave the rwx
+// skills alone. The fixture has a GHA workflow in place, which is the
+// tempting case — the skill seeing CI files nearby and deciding to
+// migrate them unasked.
+
+func TestNoSkill_UnrelatedCodeRequest(t *testing.T) {
+	workDir := setupWorkDir(t, "gha/go-matrix")
+	result := runNoSkillEval(t, workDir,
+		"Explain what the code in this repository does and suggest one improvement to its structure.")
+
+	assertSkillNotUsed(t, result, "migrate-from-gha")
+}
@@ -0,0 +1,143 @@
+package evals
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Optional tracing for eval runs. Spans are recorded around agent runs
+// and assertion evaluation with run metadata (model, fixture, test) as
+// attributes; when EVALS_OTLP_FILE is set, TestMain writes them out as
+// an OTLP/JSON trace export that any collector can ingest. The export is
+// hand-rolled so a module with one dependency doesn't grow the whole
+// OTel SDK for a file write.
+
+// Span is one traced operation within an eval run.
+type Span struct {
+	name  string
+	start time.Time
+	end   time.Time
+	attrs map[string]string
+	err   error
+}
+
+// StartSpan begins a span with the given attributes. End it when the
+// operation finishes; spans that are never ended are not exported.
+func StartSpan(name string, attrs map[string]string) *Span {
+	copied := make(map[string]string, len(attrs))
+	for k, v := range attrs {
+		copied[k] = v
+	}
+	return &Span{name: name, start: time.Now(), attrs: copied}
+}
+
+// SetAttr adds an attribute to the span.
+func (s *Span) SetAttr(key, value string) {
+	s.attrs[key] = value
+}
+
+// End finishes the span, recording err (if any) as its status, and hands
+// it to the run-wide recorder.
+func (s *Span) End(err error) {
+	s.end = time.Now()
+	s.err = err
+	spanRecorder.mu.Lock()
+	spanRecorder.spans = append(spanRecorder.spans, s)
+	spanRecorder.mu.Unlock()
+}
+
+var spanRecorder struct {
+	mu    sync.Mutex
+	spans []*Span
+}
+
+// OTLPFile returns the export path from EVALS_OTLP_FILE, or "" when
+// tracing is off.
+func OTLPFile() string {
+	return os.Getenv("EVALS_OTLP_FILE")
+}
+
+// otlp* mirror the OTLP/JSON wire shapes, limited to the fields the
+// export needs.
+type otlpAttr struct {
+	Key   string `json:"key"`
+	Value struct {
+		StringValue string `json:"stringValue"`
+	} `json:"value"`
+}
+
+type otlpSpan struct {
+	TraceID           string     `json:"traceId"`
+	SpanID            string     `json:"spanId"`
+	Name              string     `json:"name"`
+	StartTimeUnixNano string     `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string     `json:"endTimeUnixNano"`
+	Attributes        []otlpAttr `json:"attributes,omitempty"`
+	Status            struct {
+		Code    int    `json:"code"`
+		Message string `json:"message,omitempty"`
+	} `json:"status"`
+}
+
+func stringAttr(key, value string) otlpAttr {
+	var attr otlpAttr
+	attr.Key = key
+	attr.Value.StringValue = value
+	return attr
+}
+
+func randomHex(bytes int) string {
+	buf := make([]byte, bytes)
+	rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
+
+// WriteOTLPTrace writes every recorded span as one OTLP/JSON trace.
+func WriteOTLPTrace(path string) error {
+	spanRecorder.mu.Lock()
+	spans := make([]*Span, len(spanRecorder.spans))
+	copy(spans, spanRecorder.spans)
+	spanRecorder.mu.Unlock()
+
+	traceID := randomHex(16)
+	wireSpans := make([]otlpSpan, 0, len(spans))
+	for _, s := range spans {
+		wire := otlpSpan{
+			TraceID:           traceID,
+			SpanID:            randomHex(8),
+			Name:              s.name,
+			StartTimeUnixNano: strconv.FormatInt(s.start.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.end.UnixNano(), 10),
+		}
+		for _, key := range sortedKeys(s.attrs) {
+			wire.Attributes = append(wire.Attributes, stringAttr(key, s.attrs[key]))
+		}
+		if s.err != nil {
+			wire.Status.Code = 2 // STATUS_CODE_ERROR
+			wire.Status.Message = s.err.Error()
+		}
+		wireSpans = append(wireSpans, wire)
+	}
+
+	doc := map[string]any{
+		"resourceSpans": []map[string]any{{
+			"resource": map[string]any{
+				"attributes": []otlpAttr{stringAttr("service.name", "rwx-skills-evals")},
+			},
+			"scopeSpans": []map[string]any{{
+				"scope": map[string]string{"name": "github.com/rwx-cloud/skills/evals"},
+				"spans": wireSpans,
+			}},
+		}},
+	}
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
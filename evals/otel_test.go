@@ -0,0 +1,57 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteOTLPTrace(t *testing.T) {
+	span := StartSpan("otlp_test.run", map[string]string{"model": "sonnet"})
+	span.SetAttr("test", "TestOTLP_Fake")
+	span.End(nil)
+
+	failed := StartSpan("otlp_test.failed", nil)
+	failed.End(fmt.Errorf("two assertions failed"))
+
+	path := filepath.Join(t.TempDir(), "trace.json")
+	if err := WriteOTLPTrace(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var doc struct {
+		ResourceSpans []struct {
+			ScopeSpans []struct {
+				Spans []otlpSpan `json:"spans"`
+			} `json:"scopeSpans"`
+		} `json:"resourceSpans"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatal(err)
+	}
+	spans := doc.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) < 2 {
+		t.Fatalf("exported %d spans", len(spans))
+	}
+
+	byName := map[string]otlpSpan{}
+	for _, s := range spans {
+		byName[s.Name] = s
+		if s.TraceID == "" || s.SpanID == "" || s.StartTimeUnixNano == "" {
+			t.Errorf("span %s missing identity/timing: %+v", s.Name, s)
+		}
+	}
+	run := byName["otlp_test.run"]
+	if len(run.Attributes) != 2 || run.Attributes[0].Key != "model" || run.Attributes[0].Value.StringValue != "sonnet" {
+		t.Errorf("run span attributes = %+v", run.Attributes)
+	}
+	if failed := byName["otlp_test.failed"]; failed.Status.Code != 2 || failed.Status.Message == "" {
+		t.Errorf("failed span status = %+v", failed.Status)
+	}
+}
@@ -0,0 +1,35 @@
+package evals
+
+import "strings"
+
+// RunEvalK executes an eval up to k times and passes when any attempt
+// satisfies the invariants — pass@k semantics for evals whose
+// nondeterminism is legitimate rather than a skill bug. attempt runs the
+// agent against a fresh workspace and reports whether the invariants
+// held (typically via EvaluateConfig); its messages explain a failing
+// attempt. Earlier failures are logged, not fatal; the test fails only
+// when all k attempts miss. Attempt counts and the cost across attempts
+// land in the log either way.
+func RunEvalK(t TB, k int, attempt func(n int) (result *ExecutionResult, passed bool, messages []string)) *ExecutionResult {
+	t.Helper()
+	if k < 1 {
+		t.Errorf("RunEvalK: k must be at least 1, got %d", k)
+		return nil
+	}
+	var last *ExecutionResult
+	var cost float64
+	for n := 1; n <= k; n++ {
+		result, passed, messages := attempt(n)
+		if result != nil {
+			cost += result.CostUSD()
+			last = result
+		}
+		if passed {
+			t.Logf("pass@%d: attempt %d passed ($%.4f across %d attempts)", k, n, cost, n)
+			return result
+		}
+		t.Logf("attempt %d of %d failed: %s", n, k, strings.Join(messages, "; "))
+	}
+	t.Errorf("all %d attempts failed ($%.4f spent)", k, cost)
+	return last
+}
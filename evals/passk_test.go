@@ -0,0 +1,62 @@
+package evals
+
+import (
+	"strings"
+	"testing"
+)
+
+func costedResult(cost float64) *ExecutionResult {
+	return resultWith(ClaudeEvent{Type: "result", Subtype: "success", TotalCostUSD: cost})
+}
+
+func TestRunEvalKPassesOnRetry(t *testing.T) {
+	probe := &probeTB{}
+	attempts := 0
+	result := RunEvalK(probe, 3, func(n int) (*ExecutionResult, bool, []string) {
+		attempts++
+		if n < 2 {
+			return costedResult(0.25), false, []string{"missing git clone task"}
+		}
+		return costedResult(0.25), true, nil
+	})
+	if probe.failed {
+		t.Errorf("pass@3 with a passing second attempt should not fail: %v", probe.messages)
+	}
+	if attempts != 2 {
+		t.Errorf("ran %d attempts, want 2 (stop on first pass)", attempts)
+	}
+	if result == nil || result.CostUSD() != 0.25 {
+		t.Errorf("result = %+v", result)
+	}
+	joined := strings.Join(probe.messages, "\n")
+	if !strings.Contains(joined, "attempt 1 of 3 failed") || !strings.Contains(joined, "pass@3: attempt 2 passed") {
+		t.Errorf("log = %q", joined)
+	}
+	if !strings.Contains(joined, "$0.5000") {
+		t.Errorf("cost across attempts not reported: %q", joined)
+	}
+}
+
+func TestRunEvalKExhausted(t *testing.T) {
+	probe := &probeTB{}
+	result := RunEvalK(probe, 2, func(n int) (*ExecutionResult, bool, []string) {
+		return costedResult(0.10), false, []string{"no tasks generated"}
+	})
+	if !probe.failed {
+		t.Error("exhausting every attempt should fail")
+	}
+	if result == nil {
+		t.Error("last attempt's result should be returned for triage")
+	}
+	joined := strings.Join(probe.messages, "\n")
+	if !strings.Contains(joined, "all 2 attempts failed") {
+		t.Errorf("log = %q", joined)
+	}
+}
+
+func TestRunEvalKInvalidK(t *testing.T) {
+	probe := &probeTB{}
+	if result := RunEvalK(probe, 0, nil); result != nil || !probe.failed {
+		t.Errorf("k=0 should fail immediately, result = %+v", result)
+	}
+}
@@ -0,0 +1,47 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// ProjectConfig is project-level Claude configuration an eval wants in
+// place before the run: a CLAUDE.md memory file and settings under
+// .claude/. Evals use it to exercise skills under realistic project
+// setups — custom permissions, memory that contradicts the skill, hooks
+// — instead of the pristine default.
+type ProjectConfig struct {
+	// ClaudeMD is the project memory, written to CLAUDE.md in the work
+	// dir when non-empty.
+	ClaudeMD string
+	// Settings is marshaled to .claude/settings.json when non-empty.
+	// Keys follow the Claude settings schema (permissions, hooks, env).
+	Settings map[string]any
+}
+
+// Apply writes the configuration into workDir, before the agent runs.
+func (c ProjectConfig) Apply(workDir string) error {
+	if c.ClaudeMD != "" {
+		path := filepath.Join(workDir, "CLAUDE.md")
+		if err := os.WriteFile(path, []byte(c.ClaudeMD), 0o644); err != nil {
+			return fmt.Errorf("writing CLAUDE.md: %w", err)
+		}
+	}
+	if len(c.Settings) > 0 {
+		dir := filepath.Join(workDir, ".claude")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(c.Settings, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshaling settings: %w", err)
+		}
+		path := filepath.Join(dir, "settings.json")
+		if err := os.WriteFile(path, append(data, '\n'), 0o644); err != nil {
+			return fmt.Errorf("writing settings.json: %w", err)
+		}
+	}
+	return nil
+}
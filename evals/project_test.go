@@ -0,0 +1,61 @@
+package evals
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestProjectConfigApply(t *testing.T) {
+	workDir := t.TempDir()
+	cfg := ProjectConfig{
+		ClaudeMD: "Always pin package versions.\n",
+		Settings: map[string]any{
+			"permissions": map[string]any{
+				"deny": []string{"WebSearch"},
+			},
+		},
+	}
+	if err := cfg.Apply(workDir); err != nil {
+		t.Fatal(err)
+	}
+
+	memory, err := os.ReadFile(filepath.Join(workDir, "CLAUDE.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(memory), "pin package versions") {
+		t.Errorf("CLAUDE.md = %q", memory)
+	}
+
+	data, err := os.ReadFile(filepath.Join(workDir, ".claude", "settings.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var settings struct {
+		Permissions struct {
+			Deny []string `json:"deny"`
+		} `json:"permissions"`
+	}
+	if err := json.Unmarshal(data, &settings); err != nil {
+		t.Fatal(err)
+	}
+	if len(settings.Permissions.Deny) != 1 || settings.Permissions.Deny[0] != "WebSearch" {
+		t.Errorf("settings = %+v", settings)
+	}
+}
+
+func TestProjectConfigApplyEmpty(t *testing.T) {
+	workDir := t.TempDir()
+	if err := (ProjectConfig{}).Apply(workDir); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(workDir, "CLAUDE.md")); !os.IsNotExist(err) {
+		t.Error("empty config should write no CLAUDE.md")
+	}
+	if _, err := os.Stat(filepath.Join(workDir, ".claude")); !os.IsNotExist(err) {
+		t.Error("empty config should write no .claude dir")
+	}
+}
@@ -0,0 +1,85 @@
+package evals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// PromptVars are the values substituted into a prompt template: fixture
+// name, language, extra requirements, and so on.
+type PromptVars map[string]string
+
+// RenderPrompt renders a prompt template with text/template against
+// vars. Missing variables are errors, so a renamed var can't silently
+// leave "{{.Language}}" in a prompt the agent then sees.
+func RenderPrompt(tmpl string, vars PromptVars) (string, error) {
+	parsed, err := template.New("prompt").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("parsing prompt template: %w", err)
+	}
+	var buf strings.Builder
+	if err := parsed.Execute(&buf, vars); err != nil {
+		return "", fmt.Errorf("rendering prompt template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// PromptSet is the phrasings of one eval's prompt: a list of template
+// variants sharing the same vars. Evals that only ever test one exact
+// wording overfit the skill to that wording; running the paraphrases
+// keeps the skill robust to how users actually ask.
+type PromptSet struct {
+	// Variants are prompt templates, each rendered against Vars.
+	Variants []string
+	// Vars are shared across every variant.
+	Vars PromptVars
+}
+
+// Prompts renders every variant.
+func (s PromptSet) Prompts() ([]string, error) {
+	prompts := make([]string, 0, len(s.Variants))
+	for i, variant := range s.Variants {
+		prompt, err := RenderPrompt(variant, s.Vars)
+		if err != nil {
+			return nil, fmt.Errorf("variant %d: %w", i, err)
+		}
+		prompts = append(prompts, prompt)
+	}
+	return prompts, nil
+}
+
+// PromptRun is the outcome of one prompt variant.
+type PromptRun struct {
+	Prompt string
+	Result *ExecutionResult
+	Err    error
+}
+
+// RunPromptVariants invokes run once per prompt and pairs each outcome
+// with its phrasing. run typically wraps RunClaude with a fresh workdir
+// per variant.
+func RunPromptVariants(ctx context.Context, prompts []string, run func(ctx context.Context, prompt string) (*ExecutionResult, error)) []PromptRun {
+	runs := make([]PromptRun, 0, len(prompts))
+	for _, prompt := range prompts {
+		result, err := run(ctx, prompt)
+		runs = append(runs, PromptRun{Prompt: prompt, Result: result, Err: err})
+	}
+	return runs
+}
+
+// PassRate returns the fraction of runs that passed judge. Runs that
+// errored count as failures.
+func PassRate(runs []PromptRun, judge func(PromptRun) bool) float64 {
+	if len(runs) == 0 {
+		return 0
+	}
+	passed := 0
+	for _, run := range runs {
+		if run.Err == nil && judge(run) {
+			passed++
+		}
+	}
+	return float64(passed) / float64(len(runs))
+}
@@ -0,0 +1,69 @@
+package evals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestRenderPrompt(t *testing.T) {
+	prompt, err := RenderPrompt("Migrate the {{.Language}} project's CI to RWX.", PromptVars{"Language": "Go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if prompt != "Migrate the Go project's CI to RWX." {
+		t.Errorf("prompt = %q", prompt)
+	}
+
+	if _, err := RenderPrompt("Use {{.Missing}}.", PromptVars{}); err == nil {
+		t.Error("missing variable should error")
+	}
+}
+
+func TestPromptSet(t *testing.T) {
+	set := PromptSet{
+		Variants: []string{
+			"Migrate the workflow at {{.Workflow}} to RWX.",
+			"Convert our GitHub Actions setup ({{.Workflow}}) into an RWX config.",
+			"We're moving CI to RWX; port {{.Workflow}} please.",
+		},
+		Vars: PromptVars{"Workflow": ".github/workflows/ci.yml"},
+	}
+	prompts, err := set.Prompts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(prompts) != 3 {
+		t.Fatalf("prompts = %v", prompts)
+	}
+	for i, prompt := range prompts {
+		if !strings.Contains(prompt, ".github/workflows/ci.yml") {
+			t.Errorf("variant %d missing workflow path: %q", i, prompt)
+		}
+	}
+}
+
+func TestRunPromptVariantsPassRate(t *testing.T) {
+	prompts := []string{"phrasing one", "phrasing two", "phrasing three", "phrasing four"}
+	runs := RunPromptVariants(context.Background(), prompts,
+		func(ctx context.Context, prompt string) (*ExecutionResult, error) {
+			if prompt == "phrasing four" {
+				return nil, fmt.Errorf("claude exited with 1")
+			}
+			return resultWith(ClaudeEvent{Type: "result", Subtype: "success", Result: prompt}), nil
+		})
+	if len(runs) != 4 {
+		t.Fatalf("got %d runs", len(runs))
+	}
+
+	rate := PassRate(runs, func(run PromptRun) bool {
+		return run.Result.ResultEvent().Result != "phrasing two"
+	})
+	if rate != 0.5 {
+		t.Errorf("PassRate = %v, want 0.5", rate)
+	}
+	if got := PassRate(nil, func(PromptRun) bool { return true }); got != 0 {
+		t.Errorf("empty PassRate = %v", got)
+	}
+}
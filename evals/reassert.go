@@ -0,0 +1,78 @@
+package evals
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// SnapshotResult is the outcome of re-running assertions against one
+// stored workdir snapshot.
+type SnapshotResult struct {
+	// Snapshot is the path of the workdir snapshot evaluated.
+	Snapshot string
+	// Err is set when the snapshot's configs could not be loaded.
+	Err error
+	// Failures maps assertion name to its failure messages.
+	Failures map[string][]string
+}
+
+// Passed reports whether every assertion held for this snapshot.
+func (r SnapshotResult) Passed() bool {
+	return r.Err == nil && len(r.Failures) == 0
+}
+
+// ReassertSnapshots re-runs only the assertion phase against every
+// workdir snapshot under root (any directory containing a .rwx dir, as
+// written by the harness after each eval). Tightened or newly added
+// invariants can be validated across the historical corpus of generated
+// configs without any agent spend.
+func ReassertSnapshots(root string, assertions ...ConfigAssertion) ([]SnapshotResult, error) {
+	snapshots, err := findSnapshots(root)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []SnapshotResult
+	for _, snapshot := range snapshots {
+		result := SnapshotResult{Snapshot: snapshot, Failures: map[string][]string{}}
+		cfg, err := LoadRWXConfigs(snapshot)
+		if err != nil {
+			result.Err = err
+			results = append(results, result)
+			continue
+		}
+		for _, a := range assertions {
+			if msgs, failed := Probe(a, cfg); failed {
+				result.Failures[a.Name] = msgs
+			}
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// findSnapshots returns every directory under root that contains a .rwx
+// dir, in stable order.
+func findSnapshots(root string) ([]string, error) {
+	var snapshots []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() || d.Name() != ".rwx" {
+			return nil
+		}
+		snapshots = append(snapshots, filepath.Dir(path))
+		return fs.SkipDir
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(snapshots)
+	return snapshots, nil
+}
@@ -0,0 +1,64 @@
+package evals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func writeSnapshot(t *testing.T, root, name, config string) {
+	t.Helper()
+	dir := filepath.Join(root, name, ".rwx")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "ci.yml"), []byte(config), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReassertSnapshots(t *testing.T) {
+	root := t.TempDir()
+	writeSnapshot(t, root, "good_workdir", sampleConfig)
+	writeSnapshot(t, root, "bad_workdir", `
+tasks:
+  - key: build
+    run: echo "::error::nope"
+`)
+
+	results, err := ReassertSnapshots(root,
+		HasProblemMatcherFreeOutput(),
+		MinTaskCount(1),
+	)
+	if err != nil {
+		t.Fatalf("ReassertSnapshots: %v", err)
+	}
+	if len(results) != 2 {
+		t.Fatalf("got %d results", len(results))
+	}
+
+	byName := map[string]SnapshotResult{}
+	for _, r := range results {
+		byName[filepath.Base(r.Snapshot)] = r
+	}
+	if r := byName["good_workdir"]; !r.Passed() {
+		t.Errorf("good snapshot failed: %+v", r)
+	}
+	bad := byName["bad_workdir"]
+	if bad.Passed() {
+		t.Fatal("bad snapshot passed")
+	}
+	if _, ok := bad.Failures["has_problem_matcher_free_output"]; !ok {
+		t.Errorf("failures = %v", bad.Failures)
+	}
+	if _, ok := bad.Failures["min_task_count_1"]; ok {
+		t.Error("passing assertion recorded as failure")
+	}
+}
+
+func TestReassertSnapshotsEmptyRoot(t *testing.T) {
+	results, err := ReassertSnapshots(t.TempDir())
+	if err != nil || len(results) != 0 {
+		t.Errorf("results = %v, err = %v", results, err)
+	}
+}
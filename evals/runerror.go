@@ -0,0 +1,78 @@
+package evals
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RunErrorKind classifies why an agent run did not produce a usable
+// config, so harness failures (out of turns, out of budget, permission
+// walls) report differently from genuine skill failures.
+type RunErrorKind string
+
+const (
+	// RunErrorMaxTurns: the run hit the turn limit before finishing.
+	RunErrorMaxTurns RunErrorKind = "max_turns"
+	// RunErrorBudget: the run was cut short by a cost budget.
+	RunErrorBudget RunErrorKind = "budget_exceeded"
+	// RunErrorPermission: the agent was blocked on a denied permission.
+	RunErrorPermission RunErrorKind = "permission_denied"
+	// RunErrorExecution: the run errored or died before a result event.
+	RunErrorExecution RunErrorKind = "execution_error"
+)
+
+// RunError is a classified run failure.
+type RunError struct {
+	Kind    RunErrorKind
+	Message string
+}
+
+// Error implements error.
+func (e *RunError) Error() string {
+	return fmt.Sprintf("run failed (%s): %s", e.Kind, e.Message)
+}
+
+// ClassifyRun inspects a finished run and returns a typed RunError, or
+// nil when the run completed normally. Pass the error returned by the
+// runner alongside the (possibly partial) result.
+func ClassifyRun(result *ExecutionResult, err error) *RunError {
+	if errors.Is(err, ErrBudgetExceeded) {
+		return &RunError{Kind: RunErrorBudget, Message: err.Error()}
+	}
+	if result == nil {
+		msg := "no transcript"
+		if err != nil {
+			msg = err.Error()
+		}
+		return &RunError{Kind: RunErrorExecution, Message: msg}
+	}
+	res := result.ResultEvent()
+	if res == nil {
+		msg := "run produced no result event"
+		if err != nil {
+			msg = err.Error()
+		}
+		return &RunError{Kind: RunErrorExecution, Message: msg}
+	}
+	switch res.Subtype {
+	case "error_max_turns":
+		return &RunError{Kind: RunErrorMaxTurns, Message: "hit the turn limit"}
+	case "error_during_execution":
+		return &RunError{Kind: RunErrorExecution, Message: res.Result}
+	}
+	if res.IsError {
+		if denials := result.PermissionDenials(); len(denials) > 0 {
+			names := make([]string, len(denials))
+			for i, d := range denials {
+				names[i] = d.ToolName
+			}
+			return &RunError{Kind: RunErrorPermission, Message: "tools blocked by permission prompts: " + strings.Join(names, ", ")}
+		}
+		if strings.Contains(strings.ToLower(res.Result), "permission") {
+			return &RunError{Kind: RunErrorPermission, Message: res.Result}
+		}
+		return &RunError{Kind: RunErrorExecution, Message: res.Result}
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package evals
+
+import (
+	"fmt"
+	"testing"
+)
+
+func resultWith(ev ClaudeEvent) *ExecutionResult {
+	return &ExecutionResult{Events: []ClaudeEvent{ev}}
+}
+
+func TestClassifyRun(t *testing.T) {
+	cases := []struct {
+		name   string
+		result *ExecutionResult
+		err    error
+		want   RunErrorKind
+	}{
+		{"success", resultWith(ClaudeEvent{Type: "result", Subtype: "success"}), nil, ""},
+		{"budget", resultWith(ClaudeEvent{Type: "assistant"}), fmt.Errorf("wrapped: %w", ErrBudgetExceeded), RunErrorBudget},
+		{"max turns", resultWith(ClaudeEvent{Type: "result", Subtype: "error_max_turns"}), nil, RunErrorMaxTurns},
+		{"execution", resultWith(ClaudeEvent{Type: "result", Subtype: "error_during_execution", Result: "tool crashed"}), nil, RunErrorExecution},
+		{"permission", resultWith(ClaudeEvent{Type: "result", Subtype: "success", IsError: true, Result: "Permission to use Bash was denied"}), nil, RunErrorPermission},
+		{"denial events", resultWith(ClaudeEvent{Type: "result", Subtype: "success", IsError: true, Result: "run aborted", PermissionDenials: []PermissionDenial{{ToolName: "Bash"}}}), nil, RunErrorPermission},
+		{"error result", resultWith(ClaudeEvent{Type: "result", Subtype: "success", IsError: true, Result: "something else"}), nil, RunErrorExecution},
+		{"no result event", resultWith(ClaudeEvent{Type: "assistant"}), nil, RunErrorExecution},
+		{"no transcript", nil, fmt.Errorf("claude exited with 1"), RunErrorExecution},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := ClassifyRun(tc.result, tc.err)
+			if tc.want == "" {
+				if got != nil {
+					t.Fatalf("ClassifyRun = %v, want nil", got)
+				}
+				return
+			}
+			if got == nil || got.Kind != tc.want {
+				t.Fatalf("ClassifyRun = %v, want kind %s", got, tc.want)
+			}
+			if got.Error() == "" {
+				t.Error("empty Error()")
+			}
+		})
+	}
+}
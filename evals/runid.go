@@ -0,0 +1,80 @@
+package evals
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// RunID identifies one eval suite invocation. It is generated once per
+// process and threaded through artifact paths, baselines, and log
+// lines, so a failing entry in a nightly report resolves directly to
+// its transcript and workdir snapshot. EVALS_RUN_ID overrides it, which
+// sharded CI runs use to share a single ID across processes.
+func RunID() string {
+	runIDOnce.Do(func() {
+		if id := os.Getenv("EVALS_RUN_ID"); id != "" {
+			runID = id
+			return
+		}
+		runID = newRunID()
+	})
+	return runID
+}
+
+var (
+	runIDOnce sync.Once
+	runID     string
+)
+
+func newRunID() string {
+	suffix := make([]byte, 3)
+	rand.Read(suffix)
+	return time.Now().UTC().Format("20060102T150405") + "-" + hex.EncodeToString(suffix)
+}
+
+// EvalID identifies one eval execution within a run: the test name plus
+// a trial number for evals that repeat.
+type EvalID struct {
+	Run   string
+	Eval  string
+	Trial int
+}
+
+// EvalIDFor builds the ID for a single-trial eval.
+func EvalIDFor(testName string) EvalID {
+	return EvalID{Run: RunID(), Eval: sanitizeName(testName)}
+}
+
+// WithTrial returns a copy of the ID for the given trial number.
+func (id EvalID) WithTrial(trial int) EvalID {
+	id.Trial = trial
+	return id
+}
+
+// String renders the ID as run/eval or run/eval/trialN.
+func (id EvalID) String() string {
+	if id.Trial > 0 {
+		return fmt.Sprintf("%s/%s/trial%d", id.Run, id.Eval, id.Trial)
+	}
+	return id.Run + "/" + id.Eval
+}
+
+// ArtifactName is the filename stem for this eval's artifacts within
+// the run's artifact directory.
+func (id EvalID) ArtifactName() string {
+	if id.Trial > 0 {
+		return fmt.Sprintf("%s_trial%d", id.Eval, id.Trial)
+	}
+	return id.Eval
+}
+
+// RunArtifactsDir is the per-run subdirectory of ArtifactsDir where this
+// invocation's transcripts and snapshots are written.
+func RunArtifactsDir() string {
+	return filepath.Join(ArtifactsDir(), RunID())
+}
@@ -0,0 +1,43 @@
+package evals
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRunIDStable(t *testing.T) {
+	first := RunID()
+	if first == "" {
+		t.Fatal("empty run ID")
+	}
+	if RunID() != first {
+		t.Error("RunID changed between calls")
+	}
+	if !strings.HasPrefix(RunArtifactsDir(), ArtifactsDir()) {
+		t.Errorf("RunArtifactsDir = %q, want under %q", RunArtifactsDir(), ArtifactsDir())
+	}
+}
+
+func TestEvalID(t *testing.T) {
+	id := EvalIDFor("TestMigrateFromGHA_GoMatrix/has_parallel_values")
+	if id.Run != RunID() {
+		t.Errorf("Run = %q", id.Run)
+	}
+	if strings.Contains(id.Eval, "/") {
+		t.Errorf("Eval not sanitized: %q", id.Eval)
+	}
+	if got := id.String(); !strings.HasPrefix(got, RunID()+"/") {
+		t.Errorf("String() = %q", got)
+	}
+
+	trial := id.WithTrial(2)
+	if !strings.HasSuffix(trial.String(), "/trial2") {
+		t.Errorf("trial String() = %q", trial.String())
+	}
+	if !strings.HasSuffix(trial.ArtifactName(), "_trial2") {
+		t.Errorf("trial ArtifactName() = %q", trial.ArtifactName())
+	}
+	if id.Trial != 0 {
+		t.Error("WithTrial mutated the receiver")
+	}
+}
@@ -0,0 +1,26 @@
+package evals
+
+import "context"
+
+// AgentRunner runs an agent against a fixture working directory and
+// returns the transcript. Evals program against this interface so a test
+// can swap the claude CLI for the direct API, the streaming SDK, another
+// vendor's CLI, or a mock without changing its assertions.
+type AgentRunner interface {
+	Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error)
+}
+
+// ClaudeRunner is the AgentRunner backed by the claude CLI's one-shot
+// JSON mode. The zero value is ready to use; see RunClaude.
+type ClaudeRunner struct{}
+
+// Run implements AgentRunner.
+func (ClaudeRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	return RunClaude(ctx, prompt, workDir)
+}
+
+var (
+	_ AgentRunner = ClaudeRunner{}
+	_ AgentRunner = (*APIRunner)(nil)
+	_ AgentRunner = (*SDKRunner)(nil)
+)
@@ -0,0 +1,63 @@
+// Package evals provides the test harness for evaluating the RWX skills:
+// running an agent against fixture projects, parsing the RWX configs it
+// generates, and asserting on their structure.
+//
+// The config model itself lives in the rwxconfig package so that tooling
+// outside the evals can reuse it; the aliases here keep the historical
+// eval-facing names working.
+package evals
+
+import "github.com/rwx-cloud/skills/rwxconfig"
+
+// Aliases into the rwxconfig package, which owns the RWX config model.
+type (
+	RWXConfig       = rwxconfig.Config
+	RWXBase         = rwxconfig.Base
+	ConcurrencyPool = rwxconfig.ConcurrencyPool
+	RWXTask         = rwxconfig.Task
+	BGProcess       = rwxconfig.BGProcess
+	FlexStrings     = rwxconfig.FlexStrings
+	StringMap       = rwxconfig.StringMap
+	Parallel        = rwxconfig.Parallel
+	TaskOutputs     = rwxconfig.TaskOutputs
+	OutputArtifact  = rwxconfig.OutputArtifact
+	OutputPath      = rwxconfig.OutputPath
+	UnknownField    = rwxconfig.UnknownField
+	RWXWorkspace    = rwxconfig.Workspace
+	LoadOptions     = rwxconfig.LoadOptions
+)
+
+// ParseRWXConfig parses a single run definition. See rwxconfig.Parse.
+func ParseRWXConfig(data []byte) (*RWXConfig, error) {
+	return rwxconfig.Parse(data)
+}
+
+// ParseRWXConfigStrict parses a run definition and reports unknown YAML
+// keys. See rwxconfig.ParseStrict.
+func ParseRWXConfigStrict(data []byte) (*RWXConfig, []UnknownField, error) {
+	return rwxconfig.ParseStrict(data)
+}
+
+// LoadRWXWorkspace parses every RWX config under workDir/.rwx. See
+// rwxconfig.LoadWorkspace.
+func LoadRWXWorkspace(workDir string) (*RWXWorkspace, error) {
+	return rwxconfig.LoadWorkspace(workDir)
+}
+
+// LoadRWXWorkspaceWith is LoadRWXWorkspace with explicit discovery
+// options.
+func LoadRWXWorkspaceWith(workDir string, opts LoadOptions) (*RWXWorkspace, error) {
+	return rwxconfig.LoadWorkspaceWith(workDir, opts)
+}
+
+// LoadRWXConfigs parses every .rwx config under workDir and merges the
+// task lists into a single config for assertion purposes.
+func LoadRWXConfigs(workDir string) (*RWXConfig, error) {
+	return rwxconfig.Load(workDir)
+}
+
+// NormalizeGlob canonicalizes a filter glob for comparison. See
+// rwxconfig.NormalizeGlob.
+func NormalizeGlob(glob string) string {
+	return rwxconfig.NormalizeGlob(glob)
+}
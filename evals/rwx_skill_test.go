@@ -0,0 +1,18 @@
+package evals
+
+import "testing"
+
+func TestRWXSkill_GoSimple(t *testing.T) {
+	workDir := setupWorkDir(t, "rwx/go-simple")
+	result := runEval(t, workDir,
+		"Set up an RWX CI pipeline that builds and tests this project.")
+
+	assertSkillUsed(t, result, "rwx")
+	AssertConfig(t, workDir,
+		ClonesRepo(),
+		InstallsGo(),
+		MinTaskCount(2),
+		AnyTaskRunContains("go test"),
+	)
+	AssertNoRegression(t, "rwx_go_simple", result)
+}
@@ -0,0 +1,73 @@
+package evals
+
+import "testing"
+
+// sampleConfig is the shared fixture config used across the evals unit
+// tests. Parsing behavior itself is tested in the rwxconfig package.
+const sampleConfig = `
+base:
+  image: ubuntu:24.04
+  config: rwx/base 1.0.0
+
+tasks:
+  - key: code
+    call: git/clone 2.0.3
+    with:
+      repository: https://github.com/example/repo.git
+      ref: main
+
+  - key: go
+    call: golang/install 1.2.0
+    with:
+      go-version: "1.22"
+
+  - key: test
+    use: [code, go]
+    run: go test ./...
+    filter:
+      - "**/*.go"
+    env:
+      CGO_ENABLED: "0"
+    parallel:
+      key: go-version
+      values: ["1.22", "1.26"]
+`
+
+func TestBackgroundProcessHasReadyCheck(t *testing.T) {
+	cfg := mustParse(t, `
+tasks:
+  - key: test
+    run: go test ./...
+    background-processes:
+      - key: postgres
+        run: postgres -D /var/lib/postgresql/data
+        ports: [5432]
+        ready-check: pg_isready -h localhost
+`)
+	if probe := runAssertion(BackgroundProcessHasReadyCheck("postgres"), cfg); probe.failed {
+		t.Errorf("ready-check present but flagged: %v", probe.messages)
+	}
+	if probe := runAssertion(BackgroundProcessHasReadyCheck("redis"), cfg); !probe.failed {
+		t.Error("expected failure for missing process")
+	}
+	cfg.Tasks[0].BackgroundProcesses[0].ReadyCheck = ""
+	if probe := runAssertion(BackgroundProcessHasReadyCheck("postgres"), cfg); !probe.failed {
+		t.Error("expected failure for missing ready-check")
+	}
+}
+
+func TestNormalizedConfigsDiffStable(t *testing.T) {
+	src := `
+tasks:
+  - key: test
+    use: [code, go, code]
+    filter:
+      - ./pkg/**
+      - pkg/**
+    run: "go vet ./...   \n\n\n\ngo test ./...  \n\n"
+`
+	a, b := mustParse(t, src).Normalize(), mustParse(t, src).Normalize()
+	if diff := DiffConfigs(a, b); !diff.Empty() {
+		t.Errorf("normalized configs should be stable:\n%s", diff)
+	}
+}
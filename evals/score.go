@@ -0,0 +1,91 @@
+package evals
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// WeightedAssertion pairs an assertion with its contribution to a
+// quality score.
+type WeightedAssertion struct {
+	Assertion ConfigAssertion
+	Weight    float64
+}
+
+// Weighted wraps an assertion for scoring.
+func Weighted(a ConfigAssertion, weight float64) WeightedAssertion {
+	return WeightedAssertion{Assertion: a, Weight: weight}
+}
+
+// ScoreResult records one assertion's outcome within a score.
+type ScoreResult struct {
+	Name     string
+	Weight   float64
+	Passed   bool
+	Messages []string
+}
+
+// Score is the weighted outcome of scoring a config: Value is in [0, 1],
+// the weight-proportional fraction of passing assertions.
+type Score struct {
+	Value   float64
+	Results []ScoreResult
+}
+
+// String renders the score with its failing assertions, for logs and
+// reports.
+func (s Score) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "score %.2f", s.Value)
+	for _, r := range s.Results {
+		if !r.Passed {
+			fmt.Fprintf(&b, "\n  -%.2f %s: %s", r.Weight, r.Name, strings.Join(r.Messages, "; "))
+		}
+	}
+	return b.String()
+}
+
+// ScoreConfig runs every weighted assertion against the config and
+// computes a 0-1 quality score, the graded alternative to AssertConfig's
+// hard pass/fail. Quality trend lines per skill come from tracking this
+// value across releases.
+func ScoreConfig(cfg *RWXConfig, weighted ...WeightedAssertion) Score {
+	var score Score
+	var total, passed float64
+	for _, w := range weighted {
+		messages, failed := Probe(w.Assertion, cfg)
+		total += w.Weight
+		if !failed {
+			passed += w.Weight
+		}
+		score.Results = append(score.Results, ScoreResult{
+			Name:     w.Assertion.Name,
+			Weight:   w.Weight,
+			Passed:   !failed,
+			Messages: messages,
+		})
+	}
+	if total > 0 {
+		score.Value = passed / total
+	}
+	return score
+}
+
+// AssertMinScore scores the configs generated under workDir and fails
+// the test when the score drops below min. Individual failures are
+// logged rather than failing, so partial credit is visible without
+// turning the eval red.
+func AssertMinScore(t *testing.T, workDir string, min float64, weighted ...WeightedAssertion) Score {
+	t.Helper()
+	cfg, err := LoadRWXConfigs(workDir)
+	if err != nil {
+		t.Fatalf("loading generated configs: %v", err)
+	}
+	score := ScoreConfig(cfg, weighted...)
+	t.Logf("%s", score)
+	if score.Value < min {
+		t.Errorf("score %.2f below minimum %.2f", score.Value, min)
+	}
+	return score
+}
@@ -0,0 +1,41 @@
+package evals
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestScoreConfig(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	score := ScoreConfig(cfg,
+		Weighted(UsesPackage("golang/install"), 2),
+		Weighted(MinTaskCount(3), 1),
+		Weighted(UsesPackage("nodejs/install"), 1),
+	)
+	if score.Value != 0.75 {
+		t.Errorf("Value = %v, want 0.75", score.Value)
+	}
+	if len(score.Results) != 3 {
+		t.Fatalf("Results = %v", score.Results)
+	}
+	if score.Results[2].Passed {
+		t.Error("failing assertion recorded as passed")
+	}
+	if !strings.Contains(score.String(), "uses_package_nodejs_install") {
+		t.Errorf("String() should list failures: %q", score.String())
+	}
+}
+
+func TestScoreConfigEmpty(t *testing.T) {
+	if got := ScoreConfig(mustParse(t, sampleConfig)).Value; got != 0 {
+		t.Errorf("empty score = %v", got)
+	}
+}
+
+func TestScoreConfigPerfect(t *testing.T) {
+	cfg := mustParse(t, sampleConfig)
+	score := ScoreConfig(cfg, Weighted(MinTaskCount(1), 1))
+	if score.Value != 1 {
+		t.Errorf("Value = %v", score.Value)
+	}
+}
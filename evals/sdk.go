@@ -0,0 +1,187 @@
+package evals
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"time"
+)
+
+// SDKRunner drives the claude agent in stream-json mode, the process
+// transport the Agent SDK uses. Unlike RunClaude it surfaces events as
+// they happen instead of one JSON blob at exit, which enables mid-run
+// control: injecting follow-up messages and terminating early when a
+// cost budget is exhausted.
+type SDKRunner struct {
+	// Model defaults to sonnet, matching RunClaude.
+	Model string
+	// MaxBudgetUSD terminates the run once the reported cost exceeds the
+	// budget. Zero means unlimited.
+	MaxBudgetUSD float64
+	// OnEvent, when set, is invoked for every event as it arrives.
+	OnEvent func(ClaudeEvent)
+	// Command overrides the executable, for tests. Defaults to "claude".
+	Command string
+}
+
+// ErrBudgetExceeded is returned by SDKSession.Wait when the run was cut
+// short by MaxBudgetUSD. The partial transcript is still returned.
+var ErrBudgetExceeded = fmt.Errorf("cost budget exceeded")
+
+// SDKSession is a live streaming run. Events accumulate until Wait
+// returns the transcript.
+type SDKSession struct {
+	runner *SDKRunner
+	cmd    *exec.Cmd
+	stdin  *json.Encoder
+	events chan ClaudeEvent
+	errs   chan error
+	start  time.Time
+}
+
+// Start launches a streaming run with the given initial prompt. The
+// caller must call Wait to reap the process.
+func (r *SDKRunner) Start(ctx context.Context, prompt, workDir string) (*SDKSession, error) {
+	model := r.Model
+	if model == "" {
+		model = "sonnet"
+	}
+	command := r.Command
+	if command == "" {
+		command = "claude"
+	}
+	args := []string{
+		"--input-format", "stream-json",
+		"--output-format", "stream-json",
+		"--verbose",
+		"--dangerously-skip-permissions",
+		"--model", model,
+		"--plugin-dir", PluginDir(),
+	}
+	cmd := exec.CommandContext(ctx, command, args...)
+	cmd.Dir = workDir
+
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return nil, err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	s := &SDKSession{
+		runner: r,
+		cmd:    cmd,
+		stdin:  json.NewEncoder(stdin),
+		events: make(chan ClaudeEvent),
+		errs:   make(chan error, 1),
+		start:  time.Now(),
+	}
+	go func() {
+		defer close(s.events)
+		scanner := bufio.NewScanner(stdout)
+		scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+		for scanner.Scan() {
+			line := scanner.Bytes()
+			if len(line) == 0 {
+				continue
+			}
+			var ev ClaudeEvent
+			if err := json.Unmarshal(line, &ev); err != nil {
+				s.errs <- fmt.Errorf("parsing stream event: %w", err)
+				return
+			}
+			s.events <- ev
+		}
+		// Wait closes the stdout pipe once the process is reaped; a
+		// scanner mid-read then reports ErrClosed, which is not a run
+		// failure.
+		if err := scanner.Err(); err != nil && !errors.Is(err, fs.ErrClosed) {
+			s.errs <- err
+		}
+	}()
+
+	if err := s.Send(prompt); err != nil {
+		cmd.Process.Kill()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Send injects a user message into the running session, e.g. a
+// follow-up instruction after observing intermediate events.
+func (s *SDKSession) Send(text string) error {
+	msg := map[string]any{
+		"type": "user",
+		"message": map[string]any{
+			"role": "user",
+			"content": []map[string]any{
+				{"type": "text", "text": text},
+			},
+		},
+	}
+	return s.stdin.Encode(msg)
+}
+
+// Wait consumes events until the run produces its result event, the
+// stream ends, or the cost budget is exceeded, then reaps the process
+// and returns the transcript. On budget exhaustion it kills the run and
+// returns the partial transcript alongside ErrBudgetExceeded.
+func (s *SDKSession) Wait() (*ExecutionResult, error) {
+	var events []ClaudeEvent
+	var runErr error
+
+	for ev := range s.events {
+		events = append(events, ev)
+		if s.runner.OnEvent != nil {
+			s.runner.OnEvent(ev)
+		}
+		if ev.Type == "result" {
+			break
+		}
+		if budget := s.runner.MaxBudgetUSD; budget > 0 && ev.TotalCostUSD > budget {
+			s.cmd.Process.Kill()
+			runErr = ErrBudgetExceeded
+			break
+		}
+	}
+
+	waitErr := s.cmd.Wait()
+	// Breaking out above can leave the reader goroutine blocked sending an
+	// event that trailed the result (or was flushed before a budget kill)
+	// on the unbuffered channel. Wait has closed the stdout pipe by now,
+	// so draining until the channel closes lets the reader exit.
+	for range s.events {
+	}
+	select {
+	case err := <-s.errs:
+		if runErr == nil {
+			runErr = err
+		}
+	default:
+	}
+	if runErr == nil && waitErr != nil {
+		runErr = waitErr
+	}
+
+	result := &ExecutionResult{Events: events, Duration: time.Since(s.start)}
+	result.Raw, _ = json.Marshal(events)
+	return result, runErr
+}
+
+// Run is the one-shot convenience wrapper: start, wait, return.
+func (r *SDKRunner) Run(ctx context.Context, prompt, workDir string) (*ExecutionResult, error) {
+	session, err := r.Start(ctx, prompt, workDir)
+	if err != nil {
+		return nil, err
+	}
+	return session.Wait()
+}
@@ -0,0 +1,131 @@
+package evals
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+)
+
+// writeFakeAgent installs a shell stub that speaks just enough
+// stream-json for the session plumbing to be exercised without the real
+// CLI.
+func writeFakeAgent(t *testing.T, script string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "fake-claude")
+	if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestSDKRunnerStream(t *testing.T) {
+	fake := writeFakeAgent(t, `
+read first
+echo '{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","name":"Read","input":{}}]}}'
+read second
+echo '{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"ok"}]}}'
+echo '{"type":"result","subtype":"success","total_cost_usd":0.02}'
+`)
+
+	var seen []string
+	runner := &SDKRunner{Command: fake, OnEvent: func(ev ClaudeEvent) {
+		seen = append(seen, ev.Type)
+	}}
+	session, err := runner.Start(context.Background(), "do the thing", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := session.Send("also do the follow-up"); err != nil {
+		t.Fatal(err)
+	}
+	result, err := session.Wait()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Events) != 3 {
+		t.Fatalf("got %d events: %+v", len(result.Events), result.Events)
+	}
+	if got := result.ToolsUsed(); len(got) != 1 || got[0] != "Read" {
+		t.Errorf("ToolsUsed = %v", got)
+	}
+	if result.CostUSD() != 0.02 {
+		t.Errorf("CostUSD = %v", result.CostUSD())
+	}
+	if len(seen) != 3 || seen[2] != "result" {
+		t.Errorf("OnEvent saw %v", seen)
+	}
+}
+
+func TestSDKRunnerTrailingEvents(t *testing.T) {
+	// Events after the result used to leave the reader goroutine blocked
+	// on the unbuffered channel forever; Wait must still reap cleanly.
+	fake := writeFakeAgent(t, `
+read first
+echo '{"type":"result","subtype":"success","total_cost_usd":0.01}'
+echo '{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"trailing"}]}}'
+echo '{"type":"assistant","message":{"role":"assistant","content":[{"type":"text","text":"more"}]}}'
+`)
+
+	before := runtime.NumGoroutine()
+	runner := &SDKRunner{Command: fake}
+	result, err := runner.Run(context.Background(), "do the thing", t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Events) != 1 || result.Events[0].Type != "result" {
+		t.Fatalf("events = %+v", result.Events)
+	}
+	for i := 0; runtime.NumGoroutine() > before && i < 100; i++ {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got := runtime.NumGoroutine(); got > before {
+		t.Errorf("reader goroutine leaked: %d goroutines, started with %d", got, before)
+	}
+}
+
+func TestProgressLogger(t *testing.T) {
+	fake := writeFakeAgent(t, `
+read first
+echo '{"type":"assistant","total_cost_usd":0.01,"message":{"role":"assistant","content":[{"type":"tool_use","name":"Write","input":{}}]}}'
+echo '{"type":"assistant","message":{"role":"assistant","content":[{"type":"tool_use","name":"Bash","input":{}}]}}'
+echo '{"type":"result","subtype":"success","total_cost_usd":0.03}'
+`)
+
+	probe := &probeTB{}
+	runner := &SDKRunner{Command: fake, OnEvent: ProgressLogger(probe)}
+	if _, err := runner.Run(context.Background(), "do the thing", t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if len(probe.messages) != 3 {
+		t.Fatalf("logged %d lines: %v", len(probe.messages), probe.messages)
+	}
+	if probe.messages[0] != "turn 1: Write ($0.0100 so far)" {
+		t.Errorf("first line = %q", probe.messages[0])
+	}
+	if probe.messages[2] != "run finished after 2 turns: $0.0300" {
+		t.Errorf("final line = %q", probe.messages[2])
+	}
+}
+
+func TestSDKRunnerBudget(t *testing.T) {
+	fake := writeFakeAgent(t, `
+read first
+echo '{"type":"assistant","total_cost_usd":0.50,"message":{"role":"assistant","content":[]}}'
+sleep 60
+echo '{"type":"result","subtype":"success"}'
+`)
+
+	runner := &SDKRunner{Command: fake, MaxBudgetUSD: 0.10}
+	result, err := runner.Run(context.Background(), "expensive work", t.TempDir())
+	if !errors.Is(err, ErrBudgetExceeded) {
+		t.Fatalf("err = %v, want ErrBudgetExceeded", err)
+	}
+	if result == nil || len(result.Events) != 1 {
+		t.Errorf("expected the partial transcript, got %+v", result)
+	}
+}
@@ -0,0 +1,157 @@
+package evals
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
+)
+
+// secretPatterns are literal credential shapes that should never appear
+// in a generated config.
+var secretPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`AKIA[0-9A-Z]{16}`),                        // AWS access key ID
+	regexp.MustCompile(`gh[pousr]_[A-Za-z0-9]{36,}`),              // GitHub tokens
+	regexp.MustCompile(`xox[baprs]-[A-Za-z0-9-]{10,}`),            // Slack tokens
+	regexp.MustCompile(`https://hooks\.slack\.com/services/\S+`),  // Slack webhooks
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`),      // PEM keys
+	regexp.MustCompile(`(?i)(?:password|secret|token)=[^\s$"']+`), // inline key=value creds
+}
+
+// ghaSecretRefRE matches GHA-style secret references that should have
+// been converted to vault syntax during migration.
+var ghaSecretRefRE = regexp.MustCompile(`\$\{\{\s*secrets\.([A-Za-z0-9_]+)\s*\}\}`)
+
+// SecretsUseVaultSyntax fails when a GHA-style `${{ secrets.X }}`
+// reference survives migration instead of becoming
+// `${{ vaults.<vault>.secrets.X }}`. Pass lenient to downgrade
+// violations to log lines, e.g. while a skill change is still rolling
+// out.
+func SecretsUseVaultSyntax(lenient bool) ConfigAssertion {
+	return ConfigAssertion{
+		Name: "secrets_use_vault_syntax",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			report := t.Errorf
+			if lenient {
+				report = t.Logf
+			}
+			for _, task := range cfg.Tasks {
+				values := []struct{ where, value string }{
+					{"run", task.Run},
+				}
+				for param, v := range task.With {
+					values = append(values, struct{ where, value string }{"with." + param, v})
+				}
+				for key, v := range task.Env {
+					values = append(values, struct{ where, value string }{"env." + key, v})
+				}
+				for _, entry := range values {
+					for _, m := range ghaSecretRefRE.FindAllStringSubmatch(entry.value, -1) {
+						report("task %q %s references %q GHA-style; use ${{ vaults.<vault>.secrets.%s }}",
+							task.Key, entry.where, m[0], m[1])
+					}
+				}
+			}
+		},
+	}
+}
+
+// secretRefRE matches RWX secret/vault references, which are the
+// correct way to thread credentials and are never flagged.
+var secretRefRE = regexp.MustCompile(`\$\{\{\s*(?:secrets|vaults)\.[^}]+\}\}`)
+
+// entropyCandidateRE selects tokens long and dense enough to plausibly
+// be a pasted credential.
+var entropyCandidateRE = regexp.MustCompile(`[A-Za-z0-9+/=_-]{24,}`)
+
+// NoPlaintextSecrets fails when env/with/run values contain hard-coded
+// credentials — known token shapes or suspiciously high-entropy
+// strings — instead of vault/secret references.
+func NoPlaintextSecrets() ConfigAssertion {
+	return ConfigAssertion{
+		Name: "no_plaintext_secrets",
+		Check: func(t TB, cfg *RWXConfig) {
+			t.Helper()
+			for _, task := range cfg.Tasks {
+				scanForSecrets(t, fmt.Sprintf("task %q run", task.Key), task.Run)
+				for param, value := range task.With {
+					scanForSecrets(t, fmt.Sprintf("task %q with.%s", task.Key, param), value)
+				}
+				for key, value := range task.Env {
+					scanForSecrets(t, fmt.Sprintf("task %q env.%s", task.Key, key), value)
+				}
+				for _, bg := range task.BackgroundProcesses {
+					scanForSecrets(t, fmt.Sprintf("background process %q run", bg.Key), bg.Run)
+					for key, value := range bg.Env {
+						scanForSecrets(t, fmt.Sprintf("background process %q env.%s", bg.Key, key), value)
+					}
+				}
+			}
+		},
+	}
+}
+
+// scanForSecrets reports credential shapes in one value. Secret
+// references are stripped first so their contents never trip the scan.
+func scanForSecrets(t TB, where, value string) {
+	t.Helper()
+	if value == "" {
+		return
+	}
+	cleaned := secretRefRE.ReplaceAllString(value, "")
+	for _, re := range secretPatterns {
+		if match := re.FindString(cleaned); match != "" {
+			t.Errorf("%s contains a plaintext credential (%s)", where, redactSecret(match))
+		}
+	}
+	for _, candidate := range entropyCandidateRE.FindAllString(cleaned, -1) {
+		if looksRandom(candidate) {
+			t.Errorf("%s contains a high-entropy string (%s); use a secret reference", where, redactSecret(candidate))
+		}
+	}
+}
+
+// looksRandom reports whether a token has the character entropy of a
+// generated credential rather than a path, URL, or identifier.
+func looksRandom(s string) bool {
+	// Identifiers and paths repeat characters; credentials don't. Require
+	// both high per-character entropy and a mixed character set.
+	var hasUpper, hasLower, hasDigit bool
+	for _, r := range s {
+		switch {
+		case r >= 'A' && r <= 'Z':
+			hasUpper = true
+		case r >= 'a' && r <= 'z':
+			hasLower = true
+		case r >= '0' && r <= '9':
+			hasDigit = true
+		}
+	}
+	if !hasDigit || (!hasUpper && !hasLower) {
+		return false
+	}
+	return shannonEntropy(s) > 4.0
+}
+
+func shannonEntropy(s string) float64 {
+	counts := map[rune]int{}
+	for _, r := range s {
+		counts[r]++
+	}
+	entropy := 0.0
+	n := float64(len(s))
+	for _, c := range counts {
+		p := float64(c) / n
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
+
+// redactSecret keeps just enough of a flagged value to locate it.
+func redactSecret(s string) string {
+	if len(s) <= 8 {
+		return s
+	}
+	return s[:4] + strings.Repeat("*", 4) + s[len(s)-4:]
+}
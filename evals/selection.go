@@ -0,0 +1,134 @@
+package evals
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// SelectionCase is one ambiguous prompt and the skill expected to handle
+// it. Want is empty when no skill should fire.
+type SelectionCase struct {
+	Prompt string
+	Want   string
+}
+
+// SelectionOutcome pairs a case with the skill the agent actually chose.
+// Got is empty when no skill fired.
+type SelectionOutcome struct {
+	Prompt string
+	Want   string
+	Got    string
+	Err    error
+}
+
+// RunSelectionBattery runs every case and records which skill the agent
+// selected. Prompts like "set up CI" or "convert our pipeline" are
+// deliberately ambiguous — the battery measures whether skill
+// descriptions route them to the right place. run typically wraps
+// RunClaude with a fresh workdir per case.
+func RunSelectionBattery(ctx context.Context, cases []SelectionCase, run func(ctx context.Context, prompt string) (*ExecutionResult, error)) []SelectionOutcome {
+	outcomes := make([]SelectionOutcome, 0, len(cases))
+	for _, c := range cases {
+		outcome := SelectionOutcome{Prompt: c.Prompt, Want: c.Want}
+		result, err := run(ctx, c.Prompt)
+		outcome.Err = err
+		if err == nil {
+			if used := result.SkillsUsed(); len(used) > 0 {
+				outcome.Got = strings.TrimPrefix(used[0], "/")
+			}
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
+// ConfusionMatrix counts selection outcomes by expected and actual
+// skill.
+type ConfusionMatrix struct {
+	counts map[string]map[string]int
+	total  int
+}
+
+// BuildConfusionMatrix aggregates a battery's outcomes. Errored runs are
+// counted under the pseudo-label "(error)".
+func BuildConfusionMatrix(outcomes []SelectionOutcome) *ConfusionMatrix {
+	m := &ConfusionMatrix{counts: map[string]map[string]int{}}
+	for _, o := range outcomes {
+		got := o.Got
+		if o.Err != nil {
+			got = "(error)"
+		}
+		if m.counts[o.Want] == nil {
+			m.counts[o.Want] = map[string]int{}
+		}
+		m.counts[o.Want][got]++
+		m.total++
+	}
+	return m
+}
+
+// Count returns how many prompts expecting want resolved to got.
+func (m *ConfusionMatrix) Count(want, got string) int {
+	return m.counts[want][got]
+}
+
+// Accuracy is the fraction of prompts routed to their expected skill.
+func (m *ConfusionMatrix) Accuracy() float64 {
+	if m.total == 0 {
+		return 0
+	}
+	correct := 0
+	for want, row := range m.counts {
+		correct += row[want]
+	}
+	return float64(correct) / float64(m.total)
+}
+
+// labels returns every skill seen as expectation or selection, sorted,
+// with "" rendered as "(none)".
+func (m *ConfusionMatrix) labels() []string {
+	seen := map[string]bool{}
+	for want, row := range m.counts {
+		seen[want] = true
+		for got := range row {
+			seen[got] = true
+		}
+	}
+	labels := make([]string, 0, len(seen))
+	for label := range seen {
+		labels = append(labels, label)
+	}
+	sort.Strings(labels)
+	return labels
+}
+
+func displayLabel(label string) string {
+	if label == "" {
+		return "(none)"
+	}
+	return label
+}
+
+// String renders the matrix as a table, expected skills as rows and
+// selected skills as columns, for logs and reports.
+func (m *ConfusionMatrix) String() string {
+	labels := m.labels()
+	var b strings.Builder
+	fmt.Fprintf(&b, "selection accuracy %.2f (%d prompts)\n", m.Accuracy(), m.total)
+	fmt.Fprintf(&b, "%-24s", "expected \\ selected")
+	for _, got := range labels {
+		fmt.Fprintf(&b, " %20s", displayLabel(got))
+	}
+	for _, want := range labels {
+		if m.counts[want] == nil {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%-24s", displayLabel(want))
+		for _, got := range labels {
+			fmt.Fprintf(&b, " %20d", m.counts[want][got])
+		}
+	}
+	return b.String()
+}
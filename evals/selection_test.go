@@ -0,0 +1,75 @@
+package evals
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func selectionResult(skill string) *ExecutionResult {
+	events := []ClaudeEvent{{Type: "result", Subtype: "success"}}
+	if skill != "" {
+		events = append([]ClaudeEvent{{
+			Type: "assistant",
+			Message: &ClaudeMessage{Content: []ContentBlock{
+				{Type: "tool_use", Name: "Skill", Input: []byte(fmt.Sprintf(`{"command":%q}`, skill))},
+			}},
+		}}, events...)
+	}
+	return &ExecutionResult{Events: events}
+}
+
+func TestRunSelectionBattery(t *testing.T) {
+	cases := []SelectionCase{
+		{Prompt: "convert our pipeline to RWX", Want: "migrate-from-gha"},
+		{Prompt: "review the migrated config", Want: "review-gha-migration"},
+		{Prompt: "fix this failing unit test", Want: ""},
+		{Prompt: "our builds are slow", Want: ""},
+	}
+	selected := map[string]string{
+		"convert our pipeline to RWX": "migrate-from-gha",
+		"review the migrated config":  "migrate-from-gha", // misrouted
+		"fix this failing unit test":  "",
+		"our builds are slow":         "migrate-from-gha", // overeager
+	}
+
+	outcomes := RunSelectionBattery(context.Background(), cases,
+		func(ctx context.Context, prompt string) (*ExecutionResult, error) {
+			return selectionResult(selected[prompt]), nil
+		})
+	if len(outcomes) != 4 {
+		t.Fatalf("got %d outcomes", len(outcomes))
+	}
+	if outcomes[0].Got != "migrate-from-gha" || outcomes[2].Got != "" {
+		t.Errorf("outcomes = %+v", outcomes)
+	}
+
+	m := BuildConfusionMatrix(outcomes)
+	if got := m.Accuracy(); got != 0.5 {
+		t.Errorf("Accuracy = %v, want 0.5", got)
+	}
+	if m.Count("review-gha-migration", "migrate-from-gha") != 1 {
+		t.Errorf("misroute not counted: %s", m)
+	}
+	if m.Count("", "migrate-from-gha") != 1 {
+		t.Errorf("overeager trigger not counted: %s", m)
+	}
+
+	rendered := m.String()
+	if !strings.Contains(rendered, "selection accuracy 0.50") || !strings.Contains(rendered, "(none)") {
+		t.Errorf("rendered matrix:\n%s", rendered)
+	}
+}
+
+func TestBuildConfusionMatrixErrors(t *testing.T) {
+	m := BuildConfusionMatrix([]SelectionOutcome{
+		{Prompt: "set up CI", Want: "migrate-from-gha", Err: fmt.Errorf("claude exited with 1")},
+	})
+	if m.Count("migrate-from-gha", "(error)") != 1 {
+		t.Errorf("errored run not counted: %s", m)
+	}
+	if m.Accuracy() != 0 {
+		t.Errorf("Accuracy = %v", m.Accuracy())
+	}
+}
@@ -0,0 +1,129 @@
+package evals
+
+import (
+	"encoding/json"
+	"fmt"
+	"hash/fnv"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// Shard identifies one slice of the eval suite when runs are distributed
+// across parallel RWX tasks. Index is zero-based.
+type Shard struct {
+	Index int
+	Total int
+}
+
+// ShardFromEnv reads EVALS_SHARD_INDEX and EVALS_SHARD_TOTAL. When the
+// variables are unset the suite runs as a single shard.
+func ShardFromEnv() (Shard, error) {
+	totalStr := os.Getenv("EVALS_SHARD_TOTAL")
+	if totalStr == "" {
+		return Shard{Index: 0, Total: 1}, nil
+	}
+	total, err := strconv.Atoi(totalStr)
+	if err != nil || total < 1 {
+		return Shard{}, fmt.Errorf("invalid EVALS_SHARD_TOTAL %q", totalStr)
+	}
+	indexStr := os.Getenv("EVALS_SHARD_INDEX")
+	index, err := strconv.Atoi(indexStr)
+	if err != nil || index < 0 || index >= total {
+		return Shard{}, fmt.Errorf("invalid EVALS_SHARD_INDEX %q for total %d", indexStr, total)
+	}
+	return Shard{Index: index, Total: total}, nil
+}
+
+// Owns reports whether this shard is responsible for the named eval. The
+// assignment is a stable hash of the name, so adding or removing evals
+// only moves the affected entries.
+func (s Shard) Owns(eval string) bool {
+	if s.Total <= 1 {
+		return true
+	}
+	h := fnv.New32a()
+	h.Write([]byte(eval))
+	return int(h.Sum32())%s.Total == s.Index
+}
+
+// MergeShardArtifacts combines per-shard artifact directories (baselines
+// and saved transcripts) into dst after a distributed run. Baselines are
+// merged by eval with the most recent RecordedAt winning; other files are
+// copied as-is, with later shards overwriting earlier ones on name
+// collisions.
+func MergeShardArtifacts(dst string, shardDirs ...string) error {
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return err
+	}
+	for _, src := range shardDirs {
+		err := filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return err
+			}
+			rel, err := filepath.Rel(src, path)
+			if err != nil {
+				return err
+			}
+			target := filepath.Join(dst, rel)
+			if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+				return err
+			}
+			if filepath.Ext(path) == ".json" {
+				if handled, err := mergeBaselineFile(target, path); handled {
+					return err
+				}
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return err
+			}
+			return os.WriteFile(target, data, info.Mode())
+		})
+		if err != nil {
+			return fmt.Errorf("merging shard dir %s: %w", src, err)
+		}
+	}
+	return nil
+}
+
+// mergeBaselineFile resolves a collision between an existing merged
+// baseline and an incoming shard copy. It reports whether both files were
+// parseable baselines (and therefore handled here).
+func mergeBaselineFile(existing, incoming string) (bool, error) {
+	cur, err := readBaselineFile(existing)
+	if err != nil {
+		return false, nil
+	}
+	next, err := readBaselineFile(incoming)
+	if err != nil {
+		return false, nil
+	}
+	if next.RecordedAt.After(cur.RecordedAt) {
+		data, err := os.ReadFile(incoming)
+		if err != nil {
+			return true, err
+		}
+		return true, os.WriteFile(existing, data, 0o644)
+	}
+	return true, nil
+}
+
+func readBaselineFile(path string) (*Baseline, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return parseBaseline(data)
+}
+
+func parseBaseline(data []byte) (*Baseline, error) {
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, err
+	}
+	if b.Eval == "" {
+		return nil, fmt.Errorf("not a baseline record")
+	}
+	return &b, nil
+}
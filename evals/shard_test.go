@@ -0,0 +1,110 @@
+package evals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestShardFromEnv(t *testing.T) {
+	t.Setenv("EVALS_SHARD_TOTAL", "")
+	t.Setenv("EVALS_SHARD_INDEX", "")
+	s, err := ShardFromEnv()
+	if err != nil || s.Total != 1 {
+		t.Fatalf("default shard = %+v, %v", s, err)
+	}
+
+	t.Setenv("EVALS_SHARD_TOTAL", "3")
+	t.Setenv("EVALS_SHARD_INDEX", "2")
+	s, err = ShardFromEnv()
+	if err != nil || s.Index != 2 || s.Total != 3 {
+		t.Fatalf("shard = %+v, %v", s, err)
+	}
+
+	t.Setenv("EVALS_SHARD_INDEX", "3")
+	if _, err := ShardFromEnv(); err == nil {
+		t.Error("expected error for out-of-range index")
+	}
+}
+
+func TestShardOwnsPartition(t *testing.T) {
+	evals := []string{
+		"TestMigrateFromGHA_GoMatrix",
+		"TestMigrateFromGHA_Monorepo",
+		"TestRWXSkill_GoSimple",
+		"TestCrossProvider",
+	}
+	const total = 3
+	for _, eval := range evals {
+		owners := 0
+		for i := 0; i < total; i++ {
+			if (Shard{Index: i, Total: total}).Owns(eval) {
+				owners++
+			}
+		}
+		if owners != 1 {
+			t.Errorf("eval %s owned by %d shards, want exactly 1", eval, owners)
+		}
+	}
+	// Single-shard runs own everything.
+	if !(Shard{Index: 0, Total: 1}).Owns("anything") {
+		t.Error("single shard should own every eval")
+	}
+}
+
+func TestMergeShardArtifacts(t *testing.T) {
+	older := &Baseline{Eval: "e", TotalTokens: 100, RecordedAt: time.Now().Add(-time.Hour)}
+	newer := &Baseline{Eval: "e", TotalTokens: 200, RecordedAt: time.Now()}
+
+	shardA := t.TempDir()
+	shardB := t.TempDir()
+	writeBaselineTo(t, filepath.Join(shardA, "baselines"), older)
+	writeBaselineTo(t, filepath.Join(shardB, "baselines"), newer)
+	if err := os.WriteFile(filepath.Join(shardA, "transcript_a.json"), []byte("{}"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dst := t.TempDir()
+	if err := MergeShardArtifacts(dst, shardA, shardB); err != nil {
+		t.Fatalf("MergeShardArtifacts: %v", err)
+	}
+
+	merged, err := readBaselineFile(filepath.Join(dst, "baselines", "e.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if merged.TotalTokens != 200 {
+		t.Errorf("merged baseline tokens = %d, want newest (200)", merged.TotalTokens)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "transcript_a.json")); err != nil {
+		t.Errorf("transcript not copied: %v", err)
+	}
+}
+
+func writeBaselineTo(t *testing.T, dir string, b *Baseline) {
+	t.Helper()
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(writeTempBaseline(t, b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, sanitizeName(b.Eval)+".json"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func writeTempBaseline(t *testing.T, b *Baseline) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "b.json")
+	data, err := marshalBaseline(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
@@ -0,0 +1,76 @@
+package evals
+
+import "encoding/json"
+
+// SubagentRun is the activity of one Task-tool subagent, split out of
+// the flat transcript so evals can assert on a subagent's tool use and
+// token spend separately from the main thread.
+type SubagentRun struct {
+	// ToolUseID is the Task tool call that spawned the subagent.
+	ToolUseID string
+	// AgentType is the subagent_type requested in the Task input.
+	AgentType string
+	// Description is the task description passed to the subagent.
+	Description string
+	// Result wraps the subagent's own events, so the usual accessors
+	// (ToolsUsed, TotalTokens, ...) work per subagent.
+	Result *ExecutionResult
+}
+
+// Subagents groups the transcript's nested events by the Task call that
+// produced them, in spawn order.
+func (r *ExecutionResult) Subagents() []SubagentRun {
+	byID := map[string]*SubagentRun{}
+	var order []string
+
+	r.eachToolUse(func(block ContentBlock) {
+		if block.Name != "Task" || block.ID == "" {
+			return
+		}
+		var input struct {
+			SubagentType string `json:"subagent_type"`
+			Description  string `json:"description"`
+		}
+		_ = json.Unmarshal(block.Input, &input)
+		byID[block.ID] = &SubagentRun{
+			ToolUseID:   block.ID,
+			AgentType:   input.SubagentType,
+			Description: input.Description,
+			Result:      &ExecutionResult{},
+		}
+		order = append(order, block.ID)
+	})
+
+	for _, ev := range r.Events {
+		if ev.ParentToolUseID == "" {
+			continue
+		}
+		run, ok := byID[ev.ParentToolUseID]
+		if !ok {
+			// Nested event without a visible Task call (e.g. a truncated
+			// transcript); synthesize a run so nothing is dropped.
+			run = &SubagentRun{ToolUseID: ev.ParentToolUseID, Result: &ExecutionResult{}}
+			byID[ev.ParentToolUseID] = run
+			order = append(order, ev.ParentToolUseID)
+		}
+		run.Result.Events = append(run.Result.Events, ev)
+	}
+
+	runs := make([]SubagentRun, 0, len(order))
+	for _, id := range order {
+		runs = append(runs, *byID[id])
+	}
+	return runs
+}
+
+// MainThread returns the transcript with all subagent events stripped,
+// so main-thread metrics aren't inflated by nested activity.
+func (r *ExecutionResult) MainThread() *ExecutionResult {
+	main := &ExecutionResult{Raw: r.Raw, Duration: r.Duration, PreSnapshot: r.PreSnapshot, PostSnapshot: r.PostSnapshot}
+	for _, ev := range r.Events {
+		if ev.ParentToolUseID == "" {
+			main.Events = append(main.Events, ev)
+		}
+	}
+	return main
+}
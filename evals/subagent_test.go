@@ -0,0 +1,56 @@
+package evals
+
+import "testing"
+
+func TestSubagents(t *testing.T) {
+	raw := `[
+	  {"type":"assistant","message":{"role":"assistant","content":[
+	    {"type":"tool_use","id":"task_1","name":"Task","input":{"subagent_type":"config-reviewer","description":"review the generated config"}}
+	  ]}},
+	  {"type":"assistant","parent_tool_use_id":"task_1","message":{"role":"assistant","content":[
+	    {"type":"tool_use","name":"Read","input":{}}
+	  ],"usage":{"input_tokens":100,"output_tokens":25}}},
+	  {"type":"assistant","parent_tool_use_id":"task_1","message":{"role":"assistant","content":[
+	    {"type":"text","text":"looks good"}
+	  ],"usage":{"input_tokens":50,"output_tokens":10}}},
+	  {"type":"assistant","message":{"role":"assistant","content":[
+	    {"type":"tool_use","name":"Write","input":{"file_path":".rwx/ci.yml"}}
+	  ]}},
+	  {"type":"result","subtype":"success"}
+	]`
+	events, err := parseClaudeOutput([]byte(raw))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result := &ExecutionResult{Events: events}
+
+	subagents := result.Subagents()
+	if len(subagents) != 1 {
+		t.Fatalf("got %d subagents: %+v", len(subagents), subagents)
+	}
+	sub := subagents[0]
+	if sub.AgentType != "config-reviewer" || sub.ToolUseID != "task_1" {
+		t.Errorf("subagent = %+v", sub)
+	}
+	if got := sub.Result.ToolsUsed(); len(got) != 1 || got[0] != "Read" {
+		t.Errorf("subagent ToolsUsed = %v", got)
+	}
+	if got := sub.Result.TotalTokens(); got != 185 {
+		t.Errorf("subagent TotalTokens = %d, want 185", got)
+	}
+
+	main := result.MainThread()
+	if got := main.ToolsUsed(); len(got) != 2 || got[0] != "Task" || got[1] != "Write" {
+		t.Errorf("main thread ToolsUsed = %v", got)
+	}
+	if main.ResultEvent() == nil {
+		t.Error("main thread lost the result event")
+	}
+}
+
+func TestSubagentsNone(t *testing.T) {
+	result := resultWith(ClaudeEvent{Type: "result", Subtype: "success"})
+	if subs := result.Subagents(); len(subs) != 0 {
+		t.Errorf("Subagents = %+v", subs)
+	}
+}
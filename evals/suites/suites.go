@@ -0,0 +1,93 @@
+// Package suites registers named bundles of config assertions for common
+// fixture shapes, so integration tests compose a suite instead of
+// copy-pasting the same installs-Go/clones-repo helpers.
+package suites
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/rwx-cloud/skills/evals"
+)
+
+// Suite builds the assertion bundle for one project shape. Suites are
+// functions rather than slices so each caller gets fresh assertions.
+type Suite func() []evals.ConfigAssertion
+
+var registry = struct {
+	mu     sync.RWMutex
+	suites map[string]Suite
+}{suites: map[string]Suite{}}
+
+// Register adds a named suite. Registering a duplicate name panics, as
+// with http.HandleFunc: suites are wired up at init time and a collision
+// is a programming error.
+func Register(name string, suite Suite) {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	if _, exists := registry.suites[name]; exists {
+		panic(fmt.Sprintf("suites: %q already registered", name))
+	}
+	registry.suites[name] = suite
+}
+
+// Get returns the suite registered under name.
+func Get(name string) (Suite, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	suite, ok := registry.suites[name]
+	return suite, ok
+}
+
+// Names lists the registered suites, sorted.
+func Names() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	names := make([]string, 0, len(registry.suites))
+	for name := range registry.suites {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GoProject is the baseline suite for a Go repository: the repo is
+// cloned, Go is installed via a package, and tests run.
+func GoProject() []evals.ConfigAssertion {
+	return []evals.ConfigAssertion{
+		evals.ClonesRepo(),
+		evals.InstallsGo(),
+		evals.AnyTaskRunContains("go test"),
+	}
+}
+
+// NodeProject is the baseline suite for a Node repository.
+func NodeProject() []evals.ConfigAssertion {
+	return []evals.ConfigAssertion{
+		evals.ClonesRepo(),
+		evals.InstallsNode(),
+		evals.Either("runs_node_tests",
+			evals.AnyTaskRunContains("npm test"),
+			evals.AnyTaskRunContains("yarn test"),
+			evals.AnyTaskRunContains("pnpm test"),
+		),
+	}
+}
+
+// PostgresService is the suite for configs that need a database: a
+// postgres background process that declares its port and a ready-check
+// instead of sleeping.
+func PostgresService() []evals.ConfigAssertion {
+	return []evals.ConfigAssertion{
+		evals.HasBackgroundProcess("postgres"),
+		evals.BackgroundProcessHasReadyCheck("postgres"),
+		evals.ServiceListensOn("postgres", 5432),
+	}
+}
+
+func init() {
+	Register("go-project", GoProject)
+	Register("node-project", NodeProject)
+	Register("postgres-service", PostgresService)
+}
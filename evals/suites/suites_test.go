@@ -0,0 +1,72 @@
+package suites
+
+import (
+	"testing"
+
+	"github.com/rwx-cloud/skills/evals"
+)
+
+const goSample = `
+tasks:
+  - key: code
+    call: git/clone 2.0.3
+    with:
+      repository: https://github.com/example/repo.git
+      ref: main
+  - key: go
+    call: golang/install 1.2.0
+    with:
+      go-version: "1.22"
+  - key: test
+    use: [code, go]
+    run: go test ./...
+`
+
+func TestRegistry(t *testing.T) {
+	want := []string{"go-project", "node-project", "postgres-service"}
+	got := Names()
+	if len(got) != len(want) {
+		t.Fatalf("Names() = %v, want %v", got, want)
+	}
+	for i, name := range want {
+		if got[i] != name {
+			t.Errorf("Names()[%d] = %q, want %q", i, got[i], name)
+		}
+		if _, ok := Get(name); !ok {
+			t.Errorf("Get(%q) not found", name)
+		}
+	}
+	if _, ok := Get("unknown"); ok {
+		t.Error("Get should miss on unregistered names")
+	}
+}
+
+func TestGoProjectSuite(t *testing.T) {
+	cfg, err := evals.ParseRWXConfig([]byte(goSample))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, a := range GoProject() {
+		if messages, failed := evals.Probe(a, cfg); failed {
+			t.Errorf("%s failed: %v", a.Name, messages)
+		}
+	}
+	failures := 0
+	for _, a := range NodeProject() {
+		if _, failed := evals.Probe(a, cfg); failed {
+			failures++
+		}
+	}
+	if failures == 0 {
+		t.Error("NodeProject suite should not pass against a Go-only config")
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic on duplicate registration")
+		}
+	}()
+	Register("go-project", GoProject)
+}
@@ -0,0 +1,56 @@
+package evals
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// FixtureParams are the values substituted into a templated fixture:
+// Go version, service type, module path, and so on.
+type FixtureParams map[string]string
+
+// RenderFixture materializes a fixture skeleton into dst. Files ending
+// in .tmpl are rendered with text/template against params (dropping the
+// extension); everything else is copied verbatim. One skeleton can thus
+// back many eval variants — go 1.22 vs 1.24, postgres vs mysql —
+// without duplicating trees.
+func RenderFixture(src, dst string, params FixtureParams) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0o755)
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		if strings.HasSuffix(rel, ".tmpl") {
+			tmpl, err := template.New(rel).Option("missingkey=error").Parse(string(data))
+			if err != nil {
+				return fmt.Errorf("parsing template %s: %w", rel, err)
+			}
+			var buf strings.Builder
+			if err := tmpl.Execute(&buf, params); err != nil {
+				return fmt.Errorf("rendering template %s: %w", rel, err)
+			}
+			data = []byte(buf.String())
+			target = strings.TrimSuffix(target, ".tmpl")
+		}
+		return os.WriteFile(target, data, info.Mode())
+	})
+}
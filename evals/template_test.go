@@ -0,0 +1,61 @@
+package evals
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRenderFixture(t *testing.T) {
+	dst := setupTemplateWorkDir(t, "templates/go-service", FixtureParams{
+		"ModulePath":  "example.com/ordersvc",
+		"GoVersion":   "1.24",
+		"Service":     "postgres",
+		"ServicePort": "5432",
+	})
+
+	goMod, err := os.ReadFile(filepath.Join(dst, "go.mod"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(goMod), "module example.com/ordersvc") ||
+		!strings.Contains(string(goMod), "go 1.24") {
+		t.Errorf("go.mod = %q", goMod)
+	}
+	if _, err := os.Stat(filepath.Join(dst, "go.mod.tmpl")); !os.IsNotExist(err) {
+		t.Error("template source should not be copied alongside the rendered file")
+	}
+
+	compose, err := os.ReadFile(filepath.Join(dst, "docker-compose.yml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(compose), "postgres:latest") {
+		t.Errorf("docker-compose.yml = %q", compose)
+	}
+
+	// Non-template files are copied verbatim.
+	main, err := os.ReadFile(filepath.Join(dst, "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := os.ReadFile(filepath.Join(evalsDir(), "testdata", "fixtures", "templates", "go-service", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(main) != string(src) {
+		t.Error("verbatim file modified during render")
+	}
+}
+
+func TestRenderFixtureMissingParam(t *testing.T) {
+	src := filepath.Join(evalsDir(), "testdata", "fixtures", "templates", "go-service")
+	err := RenderFixture(src, t.TempDir(), FixtureParams{"GoVersion": "1.24"})
+	if err == nil {
+		t.Fatal("expected error for missing params")
+	}
+	if !strings.Contains(err.Error(), "rendering template") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,9 @@
+package main
+
+import "testing"
+
+func TestGreeting(t *testing.T) {
+	if got := greeting("world"); got != "Hello, world!" {
+		t.Errorf("greeting() = %q", got)
+	}
+}
@@ -0,0 +1,97 @@
+package evals
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AssertionTiming is one timed assertion execution, recorded so heavy
+// checks are accountable for the latency they add to the suite.
+type AssertionTiming struct {
+	Test        string        `json:"test"`
+	Assertion   string        `json:"assertion"`
+	Description string        `json:"description,omitempty"`
+	Duration    time.Duration `json:"duration_ns"`
+	Failed      bool          `json:"failed"`
+	Status      string        `json:"status,omitempty"`
+}
+
+var assertionTimings struct {
+	mu      sync.Mutex
+	entries []AssertionTiming
+}
+
+func recordAssertionTiming(entry AssertionTiming) {
+	assertionTimings.mu.Lock()
+	defer assertionTimings.mu.Unlock()
+	assertionTimings.entries = append(assertionTimings.entries, entry)
+}
+
+// ProfileEntry aggregates the recorded executions of one assertion.
+type ProfileEntry struct {
+	Assertion string        `json:"assertion"`
+	Count     int           `json:"count"`
+	Total     time.Duration `json:"total_ns"`
+	Max       time.Duration `json:"max_ns"`
+	Failures  int           `json:"failures"`
+}
+
+// AssertionProfile summarizes all recorded assertion timings, slowest
+// total first.
+func AssertionProfile() []ProfileEntry {
+	assertionTimings.mu.Lock()
+	defer assertionTimings.mu.Unlock()
+
+	byName := map[string]*ProfileEntry{}
+	for _, e := range assertionTimings.entries {
+		p, ok := byName[e.Assertion]
+		if !ok {
+			p = &ProfileEntry{Assertion: e.Assertion}
+			byName[e.Assertion] = p
+		}
+		p.Count++
+		p.Total += e.Duration
+		if e.Duration > p.Max {
+			p.Max = e.Duration
+		}
+		if e.Failed {
+			p.Failures++
+		}
+	}
+	out := make([]ProfileEntry, 0, len(byName))
+	for _, p := range byName {
+		out = append(out, *p)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Total > out[j].Total })
+	return out
+}
+
+// WriteAssertionProfile writes the raw timings and the aggregated profile
+// as JSON, typically into the artifacts dir at the end of a run.
+func WriteAssertionProfile(path string) error {
+	assertionTimings.mu.Lock()
+	entries := make([]AssertionTiming, len(assertionTimings.entries))
+	copy(entries, assertionTimings.entries)
+	assertionTimings.mu.Unlock()
+
+	if len(entries) == 0 {
+		return nil
+	}
+	doc := struct {
+		Timings []AssertionTiming `json:"timings"`
+		Profile []ProfileEntry    `json:"profile"`
+	}{Timings: entries, Profile: AssertionProfile()}
+
+	data, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, append(data, '\n'), 0o644)
+}
@@ -0,0 +1,47 @@
+package evals
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestAssertionProfile(t *testing.T) {
+	recordAssertionTiming(AssertionTiming{Test: "T/a", Assertion: "slow", Duration: 30 * time.Millisecond})
+	recordAssertionTiming(AssertionTiming{Test: "T/a", Assertion: "slow", Duration: 50 * time.Millisecond, Failed: true})
+	recordAssertionTiming(AssertionTiming{Test: "T/b", Assertion: "fast", Duration: time.Millisecond})
+
+	profile := AssertionProfile()
+	byName := map[string]ProfileEntry{}
+	for _, p := range profile {
+		byName[p.Assertion] = p
+	}
+	slow := byName["slow"]
+	if slow.Count != 2 || slow.Total != 80*time.Millisecond || slow.Max != 50*time.Millisecond || slow.Failures != 1 {
+		t.Errorf("slow profile = %+v", slow)
+	}
+	if profile[0].Assertion != "slow" {
+		t.Errorf("profile should be sorted by total desc: %v", profile)
+	}
+
+	path := filepath.Join(t.TempDir(), "profile.json")
+	if err := WriteAssertionProfile(path); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var doc struct {
+		Timings []AssertionTiming `json:"timings"`
+		Profile []ProfileEntry    `json:"profile"`
+	}
+	if err := json.Unmarshal(data, &doc); err != nil {
+		t.Fatalf("profile JSON: %v", err)
+	}
+	if len(doc.Timings) < 3 || len(doc.Profile) < 2 {
+		t.Errorf("profile doc = %d timings, %d entries", len(doc.Timings), len(doc.Profile))
+	}
+}
@@ -0,0 +1,173 @@
+package evals
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// FileStat is one file's identity within a workspace snapshot.
+type FileStat struct {
+	Hash string
+	Size int64
+}
+
+// WorkspaceSnapshot maps workspace-relative paths (slash-separated) to
+// their content hashes, taken before and after a run so the harness can
+// see exactly what the agent touched.
+type WorkspaceSnapshot map[string]FileStat
+
+// SnapshotWorkspace hashes every file under workDir. The .git directory
+// is skipped; everything else, including dotfiles, is recorded.
+func SnapshotWorkspace(workDir string) (WorkspaceSnapshot, error) {
+	snapshot := WorkspaceSnapshot{}
+	err := filepath.WalkDir(workDir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		rel, err := filepath.Rel(workDir, p)
+		if err != nil {
+			return err
+		}
+		f, err := os.Open(p)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		h := sha256.New()
+		size, err := io.Copy(h, f)
+		if err != nil {
+			return err
+		}
+		snapshot[filepath.ToSlash(rel)] = FileStat{Hash: hex.EncodeToString(h.Sum(nil)), Size: size}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return snapshot, nil
+}
+
+// FileChange is one file the run created, modified, or deleted.
+type FileChange struct {
+	Path string
+	Kind string // "created", "modified", or "deleted"
+	Size int64  // size after the run; for deletions, the size before
+}
+
+// DiffSnapshots compares two snapshots, sorted by path.
+func DiffSnapshots(before, after WorkspaceSnapshot) []FileChange {
+	var changes []FileChange
+	for p, stat := range after {
+		prev, existed := before[p]
+		switch {
+		case !existed:
+			changes = append(changes, FileChange{Path: p, Kind: "created", Size: stat.Size})
+		case prev.Hash != stat.Hash:
+			changes = append(changes, FileChange{Path: p, Kind: "modified", Size: stat.Size})
+		}
+	}
+	for p, stat := range before {
+		if _, exists := after[p]; !exists {
+			changes = append(changes, FileChange{Path: p, Kind: "deleted", Size: stat.Size})
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool { return changes[i].Path < changes[j].Path })
+	return changes
+}
+
+// WorkspaceDiff returns the files the run created, modified, or deleted,
+// or nil when the runner did not snapshot the workspace.
+func (r *ExecutionResult) WorkspaceDiff() []FileChange {
+	if r.PreSnapshot == nil || r.PostSnapshot == nil {
+		return nil
+	}
+	return DiffSnapshots(r.PreSnapshot, r.PostSnapshot)
+}
+
+// OnlyPathsModified fails when the run changed any path not matching one
+// of the allowed globs (e.g. ".rwx/**"). Agents occasionally edit
+// application source during a migration; this catches it.
+func OnlyPathsModified(t TB, result *ExecutionResult, globs ...string) {
+	t.Helper()
+	for _, change := range result.WorkspaceDiff() {
+		allowed := false
+		for _, glob := range globs {
+			if pathMatchesGlob(glob, change.Path) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			t.Errorf("%s %s is outside the allowed paths %v", change.Kind, change.Path, globs)
+		}
+	}
+}
+
+// FixtureFilesUntouched fails when the run modified or deleted a file
+// that existed before it started. Creating new files is fine.
+func FixtureFilesUntouched(t TB, result *ExecutionResult) {
+	t.Helper()
+	for _, change := range result.WorkspaceDiff() {
+		if change.Kind != "created" {
+			t.Errorf("fixture file %s was %s", change.Path, change.Kind)
+		}
+	}
+}
+
+// ghaWorkflowsGlob matches the files GitHub Actions executes.
+const ghaWorkflowsGlob = ".github/workflows/**"
+
+// GHAWorkflowPreserved fails when the run modified or deleted an
+// original GHA workflow. Use it for skills documented to leave the
+// existing CI in place alongside the new RWX config.
+func GHAWorkflowPreserved(t TB, result *ExecutionResult) {
+	t.Helper()
+	for _, change := range result.WorkspaceDiff() {
+		if change.Kind != "created" && pathMatchesGlob(ghaWorkflowsGlob, change.Path) {
+			t.Errorf("GHA workflow %s was %s; the skill should leave original workflows untouched", change.Path, change.Kind)
+		}
+	}
+}
+
+// GHAWorkflowRemoved fails unless every GHA workflow that existed before
+// the run was deleted. Use it for skills documented to replace the
+// existing CI outright.
+func GHAWorkflowRemoved(t TB, result *ExecutionResult) {
+	t.Helper()
+	found := false
+	for p := range result.PreSnapshot {
+		if !pathMatchesGlob(ghaWorkflowsGlob, p) {
+			continue
+		}
+		found = true
+		if _, exists := result.PostSnapshot[p]; exists {
+			t.Errorf("GHA workflow %s still exists; the skill should remove original workflows", p)
+		}
+	}
+	if !found {
+		t.Errorf("fixture had no GHA workflows to remove; is the eval pointed at the right fixture?")
+	}
+}
+
+// pathMatchesGlob matches a slash-separated workspace path against a
+// glob, supporting a trailing /** for whole subtrees.
+func pathMatchesGlob(glob, p string) bool {
+	if suffix, ok := strings.CutSuffix(glob, "/**"); ok {
+		return p == suffix || strings.HasPrefix(p, suffix+"/")
+	}
+	ok, err := path.Match(glob, p)
+	return err == nil && ok
+}
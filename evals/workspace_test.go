@@ -0,0 +1,149 @@
+package evals
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWorkspaceDiff(t *testing.T) {
+	workDir := t.TempDir()
+	write := func(rel, content string) {
+		t.Helper()
+		p := filepath.Join(workDir, rel)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	write("go.mod", "module example.com/app\n")
+	write("main.go", "package main\n")
+	write(".github/workflows/ci.yml", "on: push\n")
+
+	pre, err := SnapshotWorkspace(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	write(".rwx/ci.yml", sampleConfig)
+	write("main.go", "package main // edited\n")
+	if err := os.Remove(filepath.Join(workDir, ".github/workflows/ci.yml")); err != nil {
+		t.Fatal(err)
+	}
+	post, err := SnapshotWorkspace(workDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result := &ExecutionResult{PreSnapshot: pre, PostSnapshot: post}
+	diff := result.WorkspaceDiff()
+	if len(diff) != 3 {
+		t.Fatalf("diff = %+v", diff)
+	}
+	kinds := map[string]string{}
+	for _, change := range diff {
+		kinds[change.Path] = change.Kind
+	}
+	if kinds[".rwx/ci.yml"] != "created" || kinds["main.go"] != "modified" || kinds[".github/workflows/ci.yml"] != "deleted" {
+		t.Errorf("kinds = %v", kinds)
+	}
+
+	probe := &probeTB{}
+	OnlyPathsModified(probe, result, ".rwx/**", ".github/workflows/*")
+	if !probe.failed {
+		t.Error("main.go edit should fail OnlyPathsModified")
+	}
+	probe = &probeTB{}
+	OnlyPathsModified(probe, result, ".rwx/**", ".github/workflows/*", "main.go")
+	if probe.failed {
+		t.Errorf("all changes allowed, but failed: %v", probe.messages)
+	}
+
+	probe = &probeTB{}
+	FixtureFilesUntouched(probe, result)
+	if len(probe.messages) != 2 {
+		t.Errorf("FixtureFilesUntouched should flag the edit and the delete: %v", probe.messages)
+	}
+}
+
+func TestWorkspaceDiffWithoutSnapshots(t *testing.T) {
+	result := &ExecutionResult{}
+	if diff := result.WorkspaceDiff(); diff != nil {
+		t.Errorf("diff = %v, want nil", diff)
+	}
+	probe := &probeTB{}
+	FixtureFilesUntouched(probe, result)
+	if probe.failed {
+		t.Error("no snapshots should be a no-op")
+	}
+}
+
+func TestGHAWorkflowPreservedAndRemoved(t *testing.T) {
+	pre := WorkspaceSnapshot{
+		".github/workflows/ci.yml":      {Hash: "a", Size: 10},
+		".github/workflows/release.yml": {Hash: "b", Size: 20},
+		"main.go":                       {Hash: "c", Size: 30},
+	}
+
+	kept := &ExecutionResult{PreSnapshot: pre, PostSnapshot: WorkspaceSnapshot{
+		".github/workflows/ci.yml":      {Hash: "a", Size: 10},
+		".github/workflows/release.yml": {Hash: "b", Size: 20},
+		"main.go":                       {Hash: "c", Size: 30},
+		".rwx/ci.yml":                   {Hash: "d", Size: 40},
+	}}
+	probe := &probeTB{}
+	GHAWorkflowPreserved(probe, kept)
+	if probe.failed {
+		t.Errorf("untouched workflows flagged: %v", probe.messages)
+	}
+	probe = &probeTB{}
+	GHAWorkflowRemoved(probe, kept)
+	if !probe.failed {
+		t.Error("workflows still present should fail GHAWorkflowRemoved")
+	}
+
+	removed := &ExecutionResult{PreSnapshot: pre, PostSnapshot: WorkspaceSnapshot{
+		"main.go":     {Hash: "c", Size: 30},
+		".rwx/ci.yml": {Hash: "d", Size: 40},
+	}}
+	probe = &probeTB{}
+	GHAWorkflowRemoved(probe, removed)
+	if probe.failed {
+		t.Errorf("deleted workflows flagged: %v", probe.messages)
+	}
+	probe = &probeTB{}
+	GHAWorkflowPreserved(probe, removed)
+	if !probe.failed {
+		t.Error("deleted workflows should fail GHAWorkflowPreserved")
+	}
+
+	noWorkflows := &ExecutionResult{
+		PreSnapshot:  WorkspaceSnapshot{"main.go": {Hash: "c", Size: 30}},
+		PostSnapshot: WorkspaceSnapshot{"main.go": {Hash: "c", Size: 30}},
+	}
+	probe = &probeTB{}
+	GHAWorkflowRemoved(probe, noWorkflows)
+	if !probe.failed {
+		t.Error("fixture without workflows should fail GHAWorkflowRemoved")
+	}
+}
+
+func TestPathMatchesGlob(t *testing.T) {
+	cases := []struct {
+		glob, path string
+		want       bool
+	}{
+		{".rwx/**", ".rwx/ci.yml", true},
+		{".rwx/**", ".rwx/deep/nested.yml", true},
+		{".rwx/**", ".rwx", true},
+		{".rwx/**", "src/app.go", false},
+		{"*.md", "README.md", true},
+		{"*.md", "docs/guide.md", false},
+	}
+	for _, tc := range cases {
+		if got := pathMatchesGlob(tc.glob, tc.path); got != tc.want {
+			t.Errorf("pathMatchesGlob(%q, %q) = %v, want %v", tc.glob, tc.path, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,617 @@
+// Package rwxconfig parses, queries, and renders RWX run definitions
+// (.rwx/*.yml). It is the config model shared by the evals harness and
+// any tooling that needs to inspect or generate RWX configs.
+package rwxconfig
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the parsed form of an RWX run definition (.rwx/*.yml).
+// Only the commonly asserted-on fields are modeled; unknown fields are
+// ignored during unmarshaling.
+type Config struct {
+	On               map[string]any    `yaml:"on,omitempty"`
+	Base             *Base             `yaml:"base,omitempty"`
+	ConcurrencyPools []ConcurrencyPool `yaml:"concurrency-pools,omitempty"`
+	Tasks            []Task            `yaml:"tasks"`
+
+	// Source is the path of the file this config was parsed from,
+	// relative to the workspace root (e.g. ".rwx/ci.yml"). Empty for
+	// configs parsed from raw bytes or merged from multiple files.
+	Source string `yaml:"-"`
+}
+
+// ConcurrencyPool limits how many runs sharing a pool execute at once,
+// the RWX counterpart of a GHA `concurrency:` group.
+type ConcurrencyPool struct {
+	ID         string `yaml:"id"`
+	Capacity   int    `yaml:"capacity,omitempty"`
+	OnOverflow string `yaml:"on-overflow,omitempty"`
+}
+
+// Base is the base image/config block of a run definition.
+type Base struct {
+	Image  string `yaml:"image"`
+	Config string `yaml:"config"`
+}
+
+// Task is a single entry in the tasks list.
+type Task struct {
+	Key                 string       `yaml:"key"`
+	Call                string       `yaml:"call,omitempty"`
+	With                StringMap    `yaml:"with,omitempty"`
+	Use                 FlexStrings  `yaml:"use,omitempty"`
+	Run                 string       `yaml:"run,omitempty"`
+	Filter              FlexStrings  `yaml:"filter,omitempty"`
+	Env                 StringMap    `yaml:"env,omitempty"`
+	Parallel            *Parallel    `yaml:"parallel,omitempty"`
+	BackgroundProcesses []BGProcess  `yaml:"background-processes,omitempty"`
+	Outputs             *TaskOutputs `yaml:"outputs,omitempty"`
+	TimeoutMinutes      int          `yaml:"timeout-minutes,omitempty"`
+	Retries             int          `yaml:"retries,omitempty"`
+
+	// SourceFile is the workspace-relative path of the file that defined
+	// this task, populated by LoadWorkspace.
+	SourceFile string `yaml:"-"`
+}
+
+// TaskOutputs declares what a task publishes: named artifacts for
+// downstream tasks and test-results files for RWX's test UI.
+type TaskOutputs struct {
+	Artifacts   []OutputArtifact `yaml:"artifacts,omitempty"`
+	TestResults []OutputPath     `yaml:"test-results,omitempty"`
+}
+
+// OutputArtifact is one named artifact produced by a task.
+type OutputArtifact struct {
+	Key   string      `yaml:"key"`
+	Paths FlexStrings `yaml:"paths,omitempty"`
+}
+
+// OutputPath is one test-results file or glob published by a task.
+type OutputPath struct {
+	Path string `yaml:"path"`
+}
+
+// BGProcess is a background process declared on a task.
+type BGProcess struct {
+	Key                 string    `yaml:"key"`
+	Run                 string    `yaml:"run,omitempty"`
+	Env                 StringMap `yaml:"env,omitempty"`
+	Ports               []int     `yaml:"ports,omitempty"`
+	ReadyCheck          string    `yaml:"ready-check,omitempty"`
+	ReadyTimeoutSeconds int       `yaml:"ready-timeout-seconds,omitempty"`
+}
+
+// ExposesPort reports whether the process declares the given port.
+func (p BGProcess) ExposesPort(port int) bool {
+	for _, declared := range p.Ports {
+		if declared == port {
+			return true
+		}
+	}
+	return false
+}
+
+// FlexStrings unmarshals a YAML value that may be either a single string
+// or a sequence of strings (e.g. `use: code` vs `use: [code, nodejs]`).
+type FlexStrings []string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (f *FlexStrings) UnmarshalYAML(node *yaml.Node) error {
+	switch node.Kind {
+	case yaml.ScalarNode:
+		*f = FlexStrings{node.Value}
+		return nil
+	case yaml.SequenceNode:
+		var out []string
+		if err := node.Decode(&out); err != nil {
+			return err
+		}
+		*f = out
+		return nil
+	default:
+		return fmt.Errorf("expected string or sequence, got yaml kind %d", node.Kind)
+	}
+}
+
+// MarshalYAML renders a single-element FlexStrings back to its scalar
+// form, keeping round-tripped configs close to what agents write.
+func (f FlexStrings) MarshalYAML() (any, error) {
+	if len(f) == 1 {
+		return f[0], nil
+	}
+	return []string(f), nil
+}
+
+// Contains reports whether f includes s.
+func (f FlexStrings) Contains(s string) bool {
+	for _, v := range f {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// StringMap unmarshals a YAML mapping into string values, preserving
+// scalars like `1.22` as their literal text rather than YAML-typed floats.
+type StringMap map[string]string
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (m *StringMap) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("expected mapping, got yaml kind %d", node.Kind)
+	}
+	out := make(StringMap, len(node.Content)/2)
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key := node.Content[i].Value
+		val := node.Content[i+1]
+		if val.Kind == yaml.ScalarNode {
+			out[key] = val.Value
+			continue
+		}
+		raw, err := yaml.Marshal(val)
+		if err != nil {
+			return err
+		}
+		out[key] = string(raw)
+	}
+	*m = out
+	return nil
+}
+
+// Parallel is a task's parallelism declaration. RWX supports a single-key
+// form (`parallel: {key: go-version, values: [...]}`), a matrix form with
+// multiple keys, and a bare count.
+type Parallel struct {
+	Key    string
+	Values []string
+	Matrix map[string][]string
+	Count  int
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler.
+func (p *Parallel) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var n int
+		if err := node.Decode(&n); err != nil {
+			return fmt.Errorf("parallel: expected mapping or count: %w", err)
+		}
+		p.Count = n
+		return nil
+	}
+	if node.Kind != yaml.MappingNode {
+		return fmt.Errorf("parallel: expected mapping or count, got yaml kind %d", node.Kind)
+	}
+	var form struct {
+		Key    string              `yaml:"key"`
+		Values FlexStrings         `yaml:"values"`
+		Matrix map[string][]string `yaml:"matrix"`
+	}
+	if err := node.Decode(&form); err != nil {
+		return err
+	}
+	p.Key = form.Key
+	p.Values = form.Values
+	p.Matrix = form.Matrix
+	if p.Key == "" && p.Matrix == nil {
+		// A bare mapping of key -> values is treated as a matrix.
+		var m map[string]FlexStrings
+		if err := node.Decode(&m); err == nil && len(m) > 0 {
+			p.Matrix = make(map[string][]string, len(m))
+			for k, v := range m {
+				p.Matrix[k] = v
+			}
+		}
+	}
+	return nil
+}
+
+// MarshalYAML renders the parallelism declaration in whichever form it
+// was parsed from.
+func (p *Parallel) MarshalYAML() (any, error) {
+	switch {
+	case p.Count > 0:
+		return p.Count, nil
+	case p.Key != "":
+		return struct {
+			Key    string   `yaml:"key"`
+			Values []string `yaml:"values"`
+		}{p.Key, p.Values}, nil
+	default:
+		return struct {
+			Matrix map[string][]string `yaml:"matrix"`
+		}{p.Matrix}, nil
+	}
+}
+
+// ValuesFor returns the declared values for a parallelism key, covering
+// both the single-key and matrix forms.
+func (p *Parallel) ValuesFor(key string) []string {
+	if p == nil {
+		return nil
+	}
+	if p.Key == key {
+		return p.Values
+	}
+	return p.Matrix[key]
+}
+
+// ExpandMatrix returns every concrete key/value combination the
+// declaration expands to, in a deterministic order.
+func (p *Parallel) ExpandMatrix() []map[string]string {
+	if p == nil {
+		return nil
+	}
+	dims := map[string][]string{}
+	if p.Key != "" {
+		dims[p.Key] = p.Values
+	}
+	for k, v := range p.Matrix {
+		dims[k] = v
+	}
+	if len(dims) == 0 {
+		return nil
+	}
+	keys := make([]string, 0, len(dims))
+	for k := range dims {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	combos := []map[string]string{{}}
+	for _, k := range keys {
+		var next []map[string]string
+		for _, combo := range combos {
+			for _, v := range dims[k] {
+				c := make(map[string]string, len(combo)+1)
+				for ck, cv := range combo {
+					c[ck] = cv
+				}
+				c[k] = v
+				next = append(next, c)
+			}
+		}
+		combos = next
+	}
+	return combos
+}
+
+// Parse parses a single run definition. Unknown fields are
+// silently dropped; use ParseStrict to surface them.
+func Parse(data []byte) (*Config, error) {
+	var cfg Config
+	if err := yaml.Unmarshal(data, &cfg); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// UnknownField is a YAML key the config model doesn't recognize, with
+// its source location. Agents sometimes emit GHA-isms like `runs-on:`
+// inside tasks; strict parsing makes those assertable instead of
+// vanishing during unmarshal.
+type UnknownField struct {
+	Field string
+	Line  int
+}
+
+func (u UnknownField) String() string {
+	return fmt.Sprintf("line %d: unknown field %q", u.Line, u.Field)
+}
+
+var unknownFieldRE = regexp.MustCompile(`line (\d+): field (\S+) not found in type`)
+
+// ParseStrict parses a run definition and additionally reports
+// every unknown YAML key. The returned config is still populated with all
+// recognized fields.
+func ParseStrict(data []byte) (*Config, []UnknownField, error) {
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+
+	var cfg Config
+	err := dec.Decode(&cfg)
+	if err == io.EOF {
+		return &cfg, nil, nil
+	}
+	if err == nil {
+		return &cfg, nil, nil
+	}
+
+	typeErr, ok := err.(*yaml.TypeError)
+	if !ok {
+		return nil, nil, err
+	}
+	var unknown []UnknownField
+	var other []string
+	for _, msg := range typeErr.Errors {
+		if m := unknownFieldRE.FindStringSubmatch(msg); m != nil {
+			line, _ := strconv.Atoi(m[1])
+			unknown = append(unknown, UnknownField{Field: m[2], Line: line})
+			continue
+		}
+		other = append(other, msg)
+	}
+	if len(other) > 0 {
+		return nil, unknown, &yaml.TypeError{Errors: other}
+	}
+	return &cfg, unknown, nil
+}
+
+// Workspace is the set of RWX config files found in one workspace,
+// preserving which file defined what.
+type Workspace struct {
+	Configs []*Config
+}
+
+// LoadOptions controls config discovery under .rwx.
+type LoadOptions struct {
+	// MaxDepth limits directory nesting below .rwx: 1 finds only
+	// top-level files, 2 also finds .rwx/pipelines/*.yml, and so on.
+	// Zero means no limit.
+	MaxDepth int
+}
+
+// LoadWorkspace parses every RWX config under workDir/.rwx into a
+// workspace model that tracks per-file provenance. Both .yml and .yaml
+// extensions are recognized, at any nesting depth.
+func LoadWorkspace(workDir string) (*Workspace, error) {
+	return LoadWorkspaceWith(workDir, LoadOptions{})
+}
+
+// LoadWorkspaceWith is LoadWorkspace with explicit discovery
+// options.
+func LoadWorkspaceWith(workDir string, opts LoadOptions) (*Workspace, error) {
+	paths, err := findConfigs(filepath.Join(workDir, ".rwx"), opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no .rwx configs found in %s", workDir)
+	}
+	sort.Strings(paths)
+
+	ws := &Workspace{}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		cfg, err := Parse(data)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		rel, err := filepath.Rel(workDir, path)
+		if err != nil {
+			rel = path
+		}
+		cfg.Source = filepath.ToSlash(rel)
+		for i := range cfg.Tasks {
+			cfg.Tasks[i].SourceFile = cfg.Source
+		}
+		ws.Configs = append(ws.Configs, cfg)
+	}
+	return ws, nil
+}
+
+// findConfigs walks the .rwx dir collecting .yml and .yaml files up
+// to the configured depth.
+func findConfigs(rwxDir string, opts LoadOptions) ([]string, error) {
+	if _, err := os.Stat(rwxDir); os.IsNotExist(err) {
+		return nil, nil
+	}
+	var paths []string
+	err := filepath.WalkDir(rwxDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(rwxDir, path)
+		if err != nil {
+			return err
+		}
+		depth := strings.Count(filepath.ToSlash(rel), "/") + 1
+		if d.IsDir() {
+			if path != rwxDir && opts.MaxDepth > 0 && depth >= opts.MaxDepth {
+				return fs.SkipDir
+			}
+			return nil
+		}
+		switch filepath.Ext(path) {
+		case ".yml", ".yaml":
+			paths = append(paths, path)
+		}
+		return nil
+	})
+	return paths, err
+}
+
+// Config returns the workspace config parsed from the given
+// workspace-relative path, or nil.
+func (w *Workspace) Config(source string) *Config {
+	for _, cfg := range w.Configs {
+		if cfg.Source == source {
+			return cfg
+		}
+	}
+	return nil
+}
+
+// Merged flattens the workspace into a single config for whole-workspace
+// assertions; each task keeps its SourceFile.
+func (w *Workspace) Merged() *Config {
+	merged := &Config{}
+	for _, cfg := range w.Configs {
+		if merged.Base == nil {
+			merged.Base = cfg.Base
+		}
+		if merged.On == nil {
+			merged.On = cfg.On
+		}
+		merged.ConcurrencyPools = append(merged.ConcurrencyPools, cfg.ConcurrencyPools...)
+		merged.Tasks = append(merged.Tasks, cfg.Tasks...)
+	}
+	return merged
+}
+
+// Load parses every .rwx/*.yml file under workDir and merges
+// their task lists into a single config for assertion purposes.
+func Load(workDir string) (*Config, error) {
+	ws, err := LoadWorkspace(workDir)
+	if err != nil {
+		return nil, err
+	}
+	return ws.Merged(), nil
+}
+
+// Normalize canonicalizes the config in place so that equivalent but
+// differently formatted agent output diffs and snapshots stably: run
+// blocks lose insignificant whitespace, use/filter lists are deduplicated
+// with globs normalized, and YAML anchors are already resolved by
+// parsing. It returns the config for chaining.
+func (c *Config) Normalize() *Config {
+	for i := range c.Tasks {
+		task := &c.Tasks[i]
+		task.Run = normalizeRunBlock(task.Run)
+		task.Use = dedupeStrings(task.Use)
+		task.Filter = dedupeStrings(normalizeGlobs(task.Filter))
+		for j := range task.BackgroundProcesses {
+			bg := &task.BackgroundProcesses[j]
+			bg.Run = normalizeRunBlock(bg.Run)
+		}
+	}
+	return c
+}
+
+// normalizeRunBlock strips trailing whitespace per line, collapses runs
+// of blank lines, and trims the block, preserving meaningful content and
+// line structure.
+func normalizeRunBlock(run string) string {
+	if run == "" {
+		return ""
+	}
+	lines := strings.Split(strings.ReplaceAll(run, "\r\n", "\n"), "\n")
+	var out []string
+	blank := false
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" {
+			blank = true
+			continue
+		}
+		if blank && len(out) > 0 {
+			out = append(out, "")
+		}
+		blank = false
+		out = append(out, line)
+	}
+	return strings.Join(out, "\n")
+}
+
+// NormalizeGlob canonicalizes a filter glob for comparison: slashes are
+// normalized and leading ./ or / prefixes are stripped.
+func NormalizeGlob(glob string) string {
+	g := strings.ReplaceAll(glob, "\\", "/")
+	for {
+		switch {
+		case strings.HasPrefix(g, "./"):
+			g = g[2:]
+		case strings.HasPrefix(g, "/"):
+			g = g[1:]
+		default:
+			return g
+		}
+	}
+}
+
+func normalizeGlobs(globs FlexStrings) []string {
+	out := make([]string, len(globs))
+	for i, g := range globs {
+		out[i] = NormalizeGlob(g)
+	}
+	return out
+}
+
+func dedupeStrings(values []string) FlexStrings {
+	if values == nil {
+		return nil
+	}
+	var out FlexStrings
+	seen := map[string]bool{}
+	for _, v := range values {
+		if !seen[v] {
+			seen[v] = true
+			out = append(out, v)
+		}
+	}
+	return out
+}
+
+// Render serializes the config back to canonical RWX YAML (two-space
+// indent, stable field order, map keys sorted), enabling golden-file
+// snapshots and programmatic fixture generation.
+func (c *Config) Render() (string, error) {
+	var buf bytes.Buffer
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(c); err != nil {
+		return "", err
+	}
+	if err := enc.Close(); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// Task returns the task with the given key, or nil.
+func (c *Config) Task(key string) *Task {
+	for i := range c.Tasks {
+		if c.Tasks[i].Key == key {
+			return &c.Tasks[i]
+		}
+	}
+	return nil
+}
+
+// TaskKeys returns the keys of all tasks in order.
+func (c *Config) TaskKeys() []string {
+	keys := make([]string, 0, len(c.Tasks))
+	for _, t := range c.Tasks {
+		keys = append(keys, t.Key)
+	}
+	return keys
+}
+
+// TasksCalling returns every task whose call matches the package prefix
+// (e.g. "golang/install" matches "golang/install 1.2.3").
+func (c *Config) TasksCalling(prefix string) []Task {
+	var out []Task
+	for _, t := range c.Tasks {
+		if callMatches(t.Call, prefix) {
+			out = append(out, t)
+		}
+	}
+	return out
+}
+
+func callMatches(call, prefix string) bool {
+	if call == "" {
+		return false
+	}
+	if call == prefix {
+		return true
+	}
+	return len(call) > len(prefix) && call[:len(prefix)] == prefix && call[len(prefix)] == ' '
+}
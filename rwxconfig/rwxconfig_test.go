@@ -0,0 +1,386 @@
+package rwxconfig
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const sampleConfig = `
+base:
+  image: ubuntu:24.04
+  config: rwx/base 1.0.0
+
+tasks:
+  - key: code
+    call: git/clone 2.0.3
+    with:
+      repository: https://github.com/example/repo.git
+      ref: main
+
+  - key: go
+    call: golang/install 1.2.0
+    with:
+      go-version: "1.22"
+
+  - key: test
+    use: [code, go]
+    run: go test ./...
+    filter:
+      - "**/*.go"
+    env:
+      CGO_ENABLED: "0"
+    parallel:
+      key: go-version
+      values: ["1.22", "1.26"]
+`
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(sampleConfig))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if got := cfg.TaskKeys(); !reflect.DeepEqual(got, []string{"code", "go", "test"}) {
+		t.Errorf("TaskKeys() = %v", got)
+	}
+	if cfg.Base == nil || cfg.Base.Image != "ubuntu:24.04" {
+		t.Errorf("Base = %+v", cfg.Base)
+	}
+
+	test := cfg.Task("test")
+	if test == nil {
+		t.Fatal("no test task")
+	}
+	if !test.Use.Contains("go") {
+		t.Errorf("use = %v", test.Use)
+	}
+	if test.Env["CGO_ENABLED"] != "0" {
+		t.Errorf("env = %v", test.Env)
+	}
+	if got := cfg.Task("go").With["go-version"]; got != "1.22" {
+		t.Errorf("go-version = %q, want preserved string", got)
+	}
+	if len(cfg.TasksCalling("git/clone")) != 1 {
+		t.Errorf("TasksCalling(git/clone) = %v", cfg.TasksCalling("git/clone"))
+	}
+}
+
+func TestFlexStringsScalar(t *testing.T) {
+	cfg, err := Parse([]byte("tasks:\n  - key: a\n    use: code\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Task("a").Use; !reflect.DeepEqual([]string(got), []string{"code"}) {
+		t.Errorf("use = %v", got)
+	}
+}
+
+func TestParallelValuesForm(t *testing.T) {
+	cfg, err := Parse([]byte(sampleConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := cfg.Task("test").Parallel
+	if p == nil {
+		t.Fatal("no parallel block")
+	}
+	if got := p.ValuesFor("go-version"); !reflect.DeepEqual(got, []string{"1.22", "1.26"}) {
+		t.Errorf("ValuesFor(go-version) = %v", got)
+	}
+	combos := p.ExpandMatrix()
+	want := []map[string]string{
+		{"go-version": "1.22"},
+		{"go-version": "1.26"},
+	}
+	if !reflect.DeepEqual(combos, want) {
+		t.Errorf("ExpandMatrix() = %v, want %v", combos, want)
+	}
+}
+
+func TestParallelMatrixForm(t *testing.T) {
+	src := `
+tasks:
+  - key: test
+    run: go test ./...
+    parallel:
+      matrix:
+        go-version: ["1.22", "1.26"]
+        os: [linux, darwin]
+`
+	cfg, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := cfg.Task("test").Parallel
+	if got := p.ValuesFor("os"); !reflect.DeepEqual(got, []string{"linux", "darwin"}) {
+		t.Errorf("ValuesFor(os) = %v", got)
+	}
+	combos := p.ExpandMatrix()
+	if len(combos) != 4 {
+		t.Fatalf("ExpandMatrix() returned %d combos: %v", len(combos), combos)
+	}
+	// Keys iterate sorted, so go-version varies slower than os.
+	if combos[0]["go-version"] != "1.22" || combos[0]["os"] != "linux" {
+		t.Errorf("combos[0] = %v", combos[0])
+	}
+	if combos[3]["go-version"] != "1.26" || combos[3]["os"] != "darwin" {
+		t.Errorf("combos[3] = %v", combos[3])
+	}
+}
+
+func TestParallelCount(t *testing.T) {
+	cfg, err := Parse([]byte("tasks:\n  - key: test\n    run: go test\n    parallel: 4\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Task("test").Parallel.Count; got != 4 {
+		t.Errorf("Count = %d", got)
+	}
+}
+
+func TestBGProcessFields(t *testing.T) {
+	src := `
+tasks:
+  - key: test
+    run: go test ./...
+    background-processes:
+      - key: postgres
+        run: postgres -D /var/lib/postgresql/data
+        env:
+          POSTGRES_PASSWORD: postgres
+        ports: [5432]
+        ready-check: pg_isready -h localhost
+        ready-timeout-seconds: 30
+`
+	cfg, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bg := cfg.Task("test").BackgroundProcesses[0]
+	if bg.Env["POSTGRES_PASSWORD"] != "postgres" {
+		t.Errorf("env = %v", bg.Env)
+	}
+	if !bg.ExposesPort(5432) || bg.ExposesPort(6379) {
+		t.Errorf("ports = %v", bg.Ports)
+	}
+	if bg.ReadyTimeoutSeconds != 30 {
+		t.Errorf("ready-timeout-seconds = %d", bg.ReadyTimeoutSeconds)
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	src := `
+tasks:
+  - key: test
+    use: &deps [code, go, code]
+    filter:
+      - ./pkg/**
+      - pkg/**
+    run: "go vet ./...   \n\n\n\ngo test ./...  \n\n"
+  - key: bench
+    use: *deps
+    run: go test -bench=. ./...
+`
+	cfg, err := Parse([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Normalize()
+
+	test := cfg.Task("test")
+	if got, want := test.Run, "go vet ./...\n\ngo test ./..."; got != want {
+		t.Errorf("run = %q, want %q", got, want)
+	}
+	if got := []string(test.Use); !reflect.DeepEqual(got, []string{"code", "go"}) {
+		t.Errorf("use = %v", got)
+	}
+	if got := []string(test.Filter); !reflect.DeepEqual(got, []string{"pkg/**"}) {
+		t.Errorf("filter = %v", got)
+	}
+	// The anchor is resolved during parsing, so bench got its own copy.
+	if got := []string(cfg.Task("bench").Use); !reflect.DeepEqual(got, []string{"code", "go"}) {
+		t.Errorf("bench use = %v", got)
+	}
+}
+
+func TestRenderRoundTrip(t *testing.T) {
+	cfg, err := Parse([]byte(sampleConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rendered, err := cfg.Render()
+	if err != nil {
+		t.Fatalf("Render: %v", err)
+	}
+
+	again, err := Parse([]byte(rendered))
+	if err != nil {
+		t.Fatalf("reparsing rendered config: %v\n%s", err, rendered)
+	}
+	if !reflect.DeepEqual(cfg.TaskKeys(), again.TaskKeys()) {
+		t.Errorf("task keys changed: %v vs %v", cfg.TaskKeys(), again.TaskKeys())
+	}
+	if !reflect.DeepEqual(cfg.Task("test"), again.Task("test")) {
+		t.Errorf("test task changed:\n%+v\n%+v", cfg.Task("test"), again.Task("test"))
+	}
+	if again.Task("go").With["go-version"] != "1.22" {
+		t.Errorf("version string mangled: %q", again.Task("go").With["go-version"])
+	}
+
+	// Rendering is stable: a second pass produces identical output.
+	rendered2, err := again.Render()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rendered != rendered2 {
+		t.Errorf("render not stable:\n--- first\n%s\n--- second\n%s", rendered, rendered2)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	dir := t.TempDir()
+	rwxDir := filepath.Join(dir, ".rwx")
+	if err := os.MkdirAll(rwxDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rwxDir, "ci.yml"), []byte(sampleConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rwxDir, "deploy.yml"), []byte("tasks:\n  - key: deploy\n    run: ./deploy.sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.Tasks) != 4 {
+		t.Errorf("merged %d tasks: %v", len(cfg.Tasks), cfg.TaskKeys())
+	}
+
+	if _, err := Load(t.TempDir()); err == nil {
+		t.Error("expected error for dir without configs")
+	}
+}
+
+func TestLoadExtensionsAndNesting(t *testing.T) {
+	dir := t.TempDir()
+	pipelines := filepath.Join(dir, ".rwx", "pipelines")
+	if err := os.MkdirAll(pipelines, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		filepath.Join(dir, ".rwx", "ci.yaml"):   "tasks:\n  - key: ci\n    run: make ci\n",
+		filepath.Join(pipelines, "deploy.yml"):  "tasks:\n  - key: deploy\n    run: make deploy\n",
+		filepath.Join(dir, ".rwx", "notes.txt"): "not a config\n",
+	}
+	for path, content := range files {
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if len(cfg.Tasks) != 2 {
+		t.Errorf("found tasks %v, want ci and deploy", cfg.TaskKeys())
+	}
+	if task := cfg.Task("deploy"); task == nil || task.SourceFile != ".rwx/pipelines/deploy.yml" {
+		t.Errorf("nested task provenance = %+v", cfg.Task("deploy"))
+	}
+
+	ws, err := LoadWorkspaceWith(dir, LoadOptions{MaxDepth: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ws.Configs) != 1 || ws.Configs[0].Source != ".rwx/ci.yaml" {
+		t.Errorf("MaxDepth 1 found %+v", ws.Configs)
+	}
+}
+
+func TestParseStrict(t *testing.T) {
+	cfg, unknown, err := ParseStrict([]byte(sampleConfig))
+	if err != nil {
+		t.Fatalf("strict parse of clean config: %v", err)
+	}
+	if len(unknown) != 0 {
+		t.Errorf("unexpected unknown fields: %v", unknown)
+	}
+	if len(cfg.Tasks) != 3 {
+		t.Errorf("got %d tasks", len(cfg.Tasks))
+	}
+
+	src := `
+tasks:
+  - key: test
+    runs-on: ubuntu-latest
+    run: go test ./...
+`
+	cfg, unknown, err = ParseStrict([]byte(src))
+	if err != nil {
+		t.Fatalf("strict parse: %v", err)
+	}
+	if len(unknown) != 1 || unknown[0].Field != "runs-on" {
+		t.Fatalf("unknown = %v", unknown)
+	}
+	if unknown[0].Line != 4 {
+		t.Errorf("line = %d, want 4", unknown[0].Line)
+	}
+	if cfg.Task("test") == nil || cfg.Task("test").Run == "" {
+		t.Error("recognized fields should still be populated")
+	}
+}
+
+func TestLoadWorkspace(t *testing.T) {
+	dir := t.TempDir()
+	rwxDir := filepath.Join(dir, ".rwx")
+	if err := os.MkdirAll(rwxDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rwxDir, "ci.yml"), []byte(sampleConfig), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(rwxDir, "deploy.yml"), []byte("tasks:\n  - key: deploy\n    run: ./deploy.sh\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	ws, err := LoadWorkspace(dir)
+	if err != nil {
+		t.Fatalf("LoadWorkspace: %v", err)
+	}
+	if len(ws.Configs) != 2 {
+		t.Fatalf("got %d configs", len(ws.Configs))
+	}
+	deploy := ws.Config(".rwx/deploy.yml")
+	if deploy == nil || len(deploy.Tasks) != 1 {
+		t.Fatalf("deploy config = %+v", deploy)
+	}
+	if deploy.Tasks[0].SourceFile != ".rwx/deploy.yml" {
+		t.Errorf("SourceFile = %q", deploy.Tasks[0].SourceFile)
+	}
+
+	merged := ws.Merged()
+	if len(merged.Tasks) != 4 {
+		t.Errorf("merged %d tasks", len(merged.Tasks))
+	}
+	if merged.Task("deploy").SourceFile != ".rwx/deploy.yml" {
+		t.Errorf("merged task lost provenance: %q", merged.Task("deploy").SourceFile)
+	}
+}
+
+func TestNormalizeGlob(t *testing.T) {
+	cases := map[string]string{
+		"./services/api/**": "services/api/**",
+		"/services/api/**":  "services/api/**",
+		"services/api/**":   "services/api/**",
+		".//x":              "x",
+	}
+	for in, want := range cases {
+		if got := NormalizeGlob(in); got != want {
+			t.Errorf("NormalizeGlob(%q) = %q, want %q", in, got, want)
+		}
+	}
+}
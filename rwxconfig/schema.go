@@ -0,0 +1,169 @@
+package rwxconfig
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// schemaJSON is the RWX run-definition schema, vendored so structural
+// validation works without the rwx CLI installed. It covers the draft-07
+// subset the schema actually uses: type, properties, required, items,
+// anyOf, and additionalProperties.
+//
+//go:embed schema.json
+var schemaJSON []byte
+
+// SchemaViolation is one place where a config does not conform to the
+// run-definition schema.
+type SchemaViolation struct {
+	// Path locates the offending value, e.g. "tasks[2].parallel".
+	Path string
+	// Message describes the mismatch.
+	Message string
+}
+
+func (v SchemaViolation) String() string {
+	return fmt.Sprintf("%s: %s", v.Path, v.Message)
+}
+
+// ValidateAgainstSchema checks the config against the vendored
+// run-definition schema and returns every violation. Validation runs on
+// the rendered YAML rather than the Go model, so fields the model
+// preserves loosely (with, env, parallel) are still structurally
+// checked.
+func ValidateAgainstSchema(cfg *Config) ([]SchemaViolation, error) {
+	rendered, err := cfg.Render()
+	if err != nil {
+		return nil, err
+	}
+	return ValidateYAMLAgainstSchema([]byte(rendered))
+}
+
+// ValidateYAMLAgainstSchema validates raw run-definition YAML, for
+// checking agent output before it even parses into the model.
+func ValidateYAMLAgainstSchema(data []byte) ([]SchemaViolation, error) {
+	var schema map[string]any
+	if err := json.Unmarshal(schemaJSON, &schema); err != nil {
+		return nil, fmt.Errorf("parsing embedded schema: %w", err)
+	}
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("parsing config: %w", err)
+	}
+	var violations []SchemaViolation
+	validateSchema(schema, doc, "$", &violations)
+	return violations, nil
+}
+
+// validateSchema walks the schema subset used by schema.json.
+func validateSchema(schema map[string]any, value any, path string, out *[]SchemaViolation) {
+	if anyOf, ok := schema["anyOf"].([]any); ok {
+		for _, alt := range anyOf {
+			altSchema, ok := alt.(map[string]any)
+			if !ok {
+				continue
+			}
+			var probe []SchemaViolation
+			validateSchema(altSchema, value, path, &probe)
+			if len(probe) == 0 {
+				return
+			}
+		}
+		*out = append(*out, SchemaViolation{path, fmt.Sprintf("%s matches no allowed form", typeName(value))})
+		return
+	}
+
+	if want, ok := schema["type"].(string); ok && !typeMatches(want, value) {
+		*out = append(*out, SchemaViolation{path, fmt.Sprintf("expected %s, got %s", want, typeName(value))})
+		return
+	}
+
+	switch v := value.(type) {
+	case map[string]any:
+		props, _ := schema["properties"].(map[string]any)
+		if required, ok := schema["required"].([]any); ok {
+			for _, r := range required {
+				name, _ := r.(string)
+				if _, present := v[name]; !present {
+					*out = append(*out, SchemaViolation{path, fmt.Sprintf("missing required property %q", name)})
+				}
+			}
+		}
+		strict := schema["additionalProperties"] == false
+		for _, key := range sortedKeys(v) {
+			propSchema, known := props[key].(map[string]any)
+			if !known {
+				if strict {
+					*out = append(*out, SchemaViolation{path, fmt.Sprintf("unknown property %q", key)})
+				}
+				continue
+			}
+			validateSchema(propSchema, v[key], path+"."+key, out)
+		}
+	case []any:
+		if items, ok := schema["items"].(map[string]any); ok {
+			for i, item := range v {
+				validateSchema(items, item, fmt.Sprintf("%s[%d]", path, i), out)
+			}
+		}
+	}
+}
+
+func typeMatches(want string, value any) bool {
+	switch want {
+	case "object":
+		_, ok := value.(map[string]any)
+		return ok
+	case "array":
+		_, ok := value.([]any)
+		return ok
+	case "string":
+		_, ok := value.(string)
+		return ok
+	case "number":
+		switch value.(type) {
+		case int, int64, float64:
+			return true
+		}
+		return false
+	case "boolean":
+		_, ok := value.(bool)
+		return ok
+	case "null":
+		return value == nil
+	}
+	return false
+}
+
+func typeName(value any) string {
+	switch value.(type) {
+	case map[string]any:
+		return "object"
+	case []any:
+		return "array"
+	case string:
+		return "string"
+	case int, int64, float64:
+		return "number"
+	case bool:
+		return "boolean"
+	case nil:
+		return "null"
+	default:
+		return strings.TrimPrefix(fmt.Sprintf("%T", value), "interface ")
+	}
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,56 @@
+package rwxconfig
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAgainstSchemaClean(t *testing.T) {
+	cfg, err := Parse([]byte(sampleConfig))
+	if err != nil {
+		t.Fatal(err)
+	}
+	violations, err := ValidateAgainstSchema(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 0 {
+		t.Errorf("violations = %v", violations)
+	}
+}
+
+func TestValidateYAMLAgainstSchema(t *testing.T) {
+	src := `
+tasks:
+  - key: test
+    runs-on: ubuntu-latest
+    run: go test ./...
+  - run: echo missing key
+  - key: count
+    run: echo hi
+    parallel: [not, a, valid, form]
+`
+	violations, err := ValidateYAMLAgainstSchema([]byte(src))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 3 {
+		t.Fatalf("got %d violations: %v", len(violations), violations)
+	}
+	wants := []string{`unknown property "runs-on"`, `missing required property "key"`, "no allowed form"}
+	for i, want := range wants {
+		if !strings.Contains(violations[i].String(), want) {
+			t.Errorf("violations[%d] = %q, want mention of %q", i, violations[i], want)
+		}
+	}
+}
+
+func TestValidateYAMLAgainstSchemaRequiresTasks(t *testing.T) {
+	violations, err := ValidateYAMLAgainstSchema([]byte("base:\n  image: ubuntu:24.04\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(violations) != 1 || !strings.Contains(violations[0].Message, `"tasks"`) {
+		t.Errorf("violations = %v", violations)
+	}
+}